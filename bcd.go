@@ -0,0 +1,75 @@
+package bytecodec
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/shimmeringbee/bytecodec/bitbuffer"
+)
+
+func marshalBCD(bb *bitbuffer.BitBuffer, name string, value reflect.Value, tags reflect.StructTag) error {
+	bcdTag, err := tagBCD(tags)
+	if err != nil {
+		return err
+	}
+
+	digits := int(bcdTag.Digits)
+
+	switch {
+	case isUint(value.Kind()):
+		if err := bb.WriteBCDUint(value.Uint(), digits, bcdTag.Order); err != nil {
+			return fmt.Errorf("%w: field '%s' value %d does not fit in %d digits", err, name, value.Uint(), digits)
+		}
+
+		return nil
+	case value.Kind() == reflect.String:
+		str := value.String()
+
+		if len(str) > digits {
+			return fmt.Errorf("%w: field '%s' value '%s' does not fit in %d digits", bitbuffer.ErrorBCDOverflow, name, str, digits)
+		}
+
+		if err := bb.WriteBCD(strings.Repeat("0", digits-len(str))+str, bcdTag.Order); err != nil {
+			return fmt.Errorf("%w: field '%s' value '%s' is not numeric", err, name, str)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("%w: field '%s' of type '%v' can not be binary coded decimal", ErrUnsupportedType, name, value.Type())
+	}
+}
+
+func unmarshalBCD(bb *bitbuffer.BitBuffer, name string, value reflect.Value, tags reflect.StructTag) error {
+	bcdTag, err := tagBCD(tags)
+	if err != nil {
+		return err
+	}
+
+	digits := int(bcdTag.Digits)
+
+	switch {
+	case isUint(value.Kind()):
+		readValue, err := bb.ReadBCDUint(digits, bcdTag.Order)
+		if err != nil {
+			return fmt.Errorf("%w: field '%s'", err, name)
+		}
+
+		if value.OverflowUint(readValue) {
+			return fmt.Errorf("%w: field '%s' value %d overflows '%v'", bitbuffer.ErrorBCDOverflow, name, readValue, value.Type())
+		}
+
+		value.SetUint(readValue)
+	case value.Kind() == reflect.String:
+		str, err := bb.ReadBCD(digits, bcdTag.Order)
+		if err != nil {
+			return fmt.Errorf("%w: field '%s'", err, name)
+		}
+
+		value.SetString(str)
+	default:
+		return fmt.Errorf("%w: field '%s' of type '%v' can not be binary coded decimal", ErrUnsupportedType, name, value.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package bytecodec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shimmeringbee/bytecodec/bitbuffer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBCD(t *testing.T) {
+	t.Run("verify unsigned integers and numeric strings are marshalled as bcd", func(t *testing.T) {
+		type StructUnderTest struct {
+			Reading uint32 `bcbcd:"8"`
+			Serial  string `bcbcd:"6,low"`
+		}
+
+		instance := &StructUnderTest{Reading: 123456, Serial: "4321"}
+		actualBytes, err := Marshal(instance)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x00, 0x12, 0x34, 0x56, 0x00, 0x34, 0x12}, actualBytes)
+
+		actualStruct := &StructUnderTest{}
+		err = Unmarshal(actualBytes, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &StructUnderTest{Reading: 123456, Serial: "004321"}, actualStruct)
+	})
+
+	t.Run("verify values with too many digits error", func(t *testing.T) {
+		type StructUnderTest struct {
+			Reading uint16 `bcbcd:"2"`
+		}
+
+		_, err := Marshal(&StructUnderTest{Reading: 100})
+
+		assert.True(t, errors.Is(err, bitbuffer.ErrorBCDOverflow))
+	})
+
+	t.Run("verify non numeric strings error", func(t *testing.T) {
+		type StructUnderTest struct {
+			Serial string `bcbcd:"4"`
+		}
+
+		_, err := Marshal(&StructUnderTest{Serial: "12ab"})
+
+		assert.True(t, errors.Is(err, bitbuffer.ErrorInvalidBCD))
+	})
+
+	t.Run("verify invalid nibbles error on unmarshal", func(t *testing.T) {
+		type StructUnderTest struct {
+			Reading uint16 `bcbcd:"4"`
+		}
+
+		err := Unmarshal([]byte{0x12, 0xf4}, &StructUnderTest{})
+
+		assert.True(t, errors.Is(err, bitbuffer.ErrorInvalidBCD))
+	})
+
+	t.Run("verify decoded values which overflow the field error", func(t *testing.T) {
+		type StructUnderTest struct {
+			Reading uint8 `bcbcd:"4"`
+		}
+
+		err := Unmarshal([]byte{0x02, 0x56}, &StructUnderTest{})
+
+		assert.True(t, errors.Is(err, bitbuffer.ErrorBCDOverflow))
+	})
+
+	t.Run("verify unsupported field types error", func(t *testing.T) {
+		type StructUnderTest struct {
+			Reading bool `bcbcd:"2"`
+		}
+
+		_, err := Marshal(&StructUnderTest{})
+
+		assert.True(t, errors.Is(err, ErrUnsupportedType))
+	})
+}
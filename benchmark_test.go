@@ -0,0 +1,124 @@
+package bytecodec
+
+import (
+	"testing"
+)
+
+type benchmarkImageBlock struct {
+	Status           uint8
+	ManufacturerCode uint16
+	ImageType        uint16
+	FileVersion      uint32
+	FileOffset       uint32
+	Data             []byte `bcsliceprefix:"8"`
+}
+
+type benchmarkAttributeNames struct {
+	Names []string `bcsliceprefix:"8"`
+}
+
+func benchmarkImageBlockBytes() []byte {
+	data := []byte{0x00, 0x4b, 0x11, 0x01, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0x00, 0xff}
+	return append(data, make([]byte, 0xff)...)
+}
+
+func benchmarkAttributeNamesBytes() []byte {
+	data := []byte{0x20}
+
+	for i := 0; i < 0x20; i++ {
+		data = append(data, 0x0b)
+		data = append(data, "temperature"...)
+	}
+
+	return data
+}
+
+func BenchmarkUnmarshalBytes(b *testing.B) {
+	data := benchmarkImageBlockBytes()
+
+	b.Run("copy", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			block := benchmarkImageBlock{}
+			if err := Unmarshal(data, &block); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("zero copy", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			block := benchmarkImageBlock{}
+			if err := Unmarshal(data, &block, ZeroCopy()); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("reused", func(b *testing.B) {
+		block := benchmarkImageBlock{}
+		opts := []Option{Overwrite()}
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			if err := Unmarshal(data, &block, opts...); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkUnmarshalStrings(b *testing.B) {
+	data := benchmarkAttributeNamesBytes()
+
+	b.Run("copy", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			names := benchmarkAttributeNames{}
+			if err := Unmarshal(data, &names); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("interned", func(b *testing.B) {
+		interner := NewStringInterner(0)
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			names := benchmarkAttributeNames{}
+			if err := Unmarshal(data, &names, InternStrings(interner)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkMarshal(b *testing.B) {
+	block := benchmarkImageBlock{Data: make([]byte, 0xff)}
+
+	b.Run("new buffer", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			if _, err := Marshal(&block); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("append", func(b *testing.B) {
+		dst := make([]byte, 0, 512)
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			if _, err := MarshalAppend(dst[:0], &block); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
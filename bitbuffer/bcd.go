@@ -0,0 +1,119 @@
+package bitbuffer
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+type NibbleOrder uint8
+
+const (
+	HighNibbleFirst NibbleOrder = 0
+	LowNibbleFirst  NibbleOrder = 1
+)
+
+var ErrorInvalidBCD = errors.New("invalid binary coded decimal digit")
+var ErrorBCDOverflow = errors.New("binary coded decimal value does not fit")
+
+// WriteBCD writes a string of decimal digits as one nibble per digit. With LowNibbleFirst each pair of digits is
+// swapped within its byte, an odd final digit is written as a single nibble.
+func (bb *BitBuffer) WriteBCD(digits string, order NibbleOrder) error {
+	for _, digit := range digits {
+		if digit < '0' || digit > '9' {
+			return ErrorInvalidBCD
+		}
+	}
+
+	for i := 0; i < len(digits); i += 2 {
+		if i+1 == len(digits) {
+			return bb.WriteBits(digits[i]-'0', 4)
+		}
+
+		first, second := digits[i]-'0', digits[i+1]-'0'
+
+		if order == LowNibbleFirst {
+			first, second = second, first
+		}
+
+		if err := bb.WriteBits(first<<4|second, 8); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (bb *BitBuffer) ReadBCD(count int, order NibbleOrder) (string, error) {
+	sb := strings.Builder{}
+
+	for i := 0; i < count; i += 2 {
+		if i+1 == count {
+			nibble, err := bb.ReadBits(4)
+			if err != nil {
+				return "", err
+			}
+
+			if nibble > 9 {
+				return "", ErrorInvalidBCD
+			}
+
+			sb.WriteByte('0' + nibble)
+			break
+		}
+
+		readByte, err := bb.ReadByte()
+		if err != nil {
+			return "", err
+		}
+
+		first, second := readByte>>4, readByte&0x0f
+
+		if order == LowNibbleFirst {
+			first, second = second, first
+		}
+
+		if first > 9 || second > 9 {
+			return "", ErrorInvalidBCD
+		}
+
+		sb.WriteByte('0' + first)
+		sb.WriteByte('0' + second)
+	}
+
+	return sb.String(), nil
+}
+
+func (bb *BitBuffer) WriteBCDUint(value uint64, count int, order NibbleOrder) error {
+	digits := make([]byte, count)
+
+	for i := count - 1; i >= 0; i-- {
+		digits[i] = '0' + byte(value%10)
+		value /= 10
+	}
+
+	if value != 0 {
+		return ErrorBCDOverflow
+	}
+
+	return bb.WriteBCD(string(digits), order)
+}
+
+func (bb *BitBuffer) ReadBCDUint(count int, order NibbleOrder) (uint64, error) {
+	digits, err := bb.ReadBCD(count, order)
+	if err != nil {
+		return 0, err
+	}
+
+	readValue := uint64(0)
+
+	for _, digit := range digits {
+		if readValue > (math.MaxUint64-uint64(digit-'0'))/10 {
+			return 0, ErrorBCDOverflow
+		}
+
+		readValue = readValue*10 + uint64(digit-'0')
+	}
+
+	return readValue, nil
+}
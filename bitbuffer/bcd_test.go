@@ -0,0 +1,106 @@
+package bitbuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WriteBCD(t *testing.T) {
+	t.Run("writing digits high nibble first", func(t *testing.T) {
+		bb := NewBitBuffer()
+
+		err := bb.WriteBCD("1234", HighNibbleFirst)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x12, 0x34}, bb.Bytes())
+	})
+
+	t.Run("writing digits low nibble first", func(t *testing.T) {
+		bb := NewBitBuffer()
+
+		err := bb.WriteBCD("1234", LowNibbleFirst)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x21, 0x43}, bb.Bytes())
+	})
+
+	t.Run("writing an odd number of digits writes a final nibble", func(t *testing.T) {
+		bb := NewBitBuffer()
+
+		err := bb.WriteBCD("123", HighNibbleFirst)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x12, 0x30}, bb.Bytes())
+	})
+
+	t.Run("writing non decimal digits errors", func(t *testing.T) {
+		bb := NewBitBuffer()
+
+		err := bb.WriteBCD("12a4", HighNibbleFirst)
+
+		assert.Equal(t, ErrorInvalidBCD, err)
+	})
+
+	t.Run("writing an integer pads with leading zeros", func(t *testing.T) {
+		bb := NewBitBuffer()
+
+		err := bb.WriteBCDUint(1234, 6, HighNibbleFirst)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x00, 0x12, 0x34}, bb.Bytes())
+	})
+
+	t.Run("writing an integer with too many digits errors", func(t *testing.T) {
+		bb := NewBitBuffer()
+
+		err := bb.WriteBCDUint(12345, 4, HighNibbleFirst)
+
+		assert.Equal(t, ErrorBCDOverflow, err)
+	})
+}
+
+func Test_ReadBCD(t *testing.T) {
+	t.Run("reading digits high nibble first", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0x01, 0x23})
+
+		actualValue, err := bb.ReadBCD(4, HighNibbleFirst)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "0123", actualValue)
+	})
+
+	t.Run("reading digits low nibble first", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0x21, 0x43})
+
+		actualValue, err := bb.ReadBCDUint(4, LowNibbleFirst)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1234), actualValue)
+	})
+
+	t.Run("reading an odd number of digits reads a final nibble", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0x12, 0x3f})
+
+		actualValue, err := bb.ReadBCD(3, HighNibbleFirst)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "123", actualValue)
+	})
+
+	t.Run("reading invalid nibbles errors", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0x1a})
+
+		_, err := bb.ReadBCD(2, HighNibbleFirst)
+
+		assert.Equal(t, ErrorInvalidBCD, err)
+	})
+
+	t.Run("reading an integer which overflows 64 bits errors", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0x99, 0x99, 0x99, 0x99, 0x99, 0x99, 0x99, 0x99, 0x99, 0x99})
+
+		_, err := bb.ReadBCDUint(20, HighNibbleFirst)
+
+		assert.Equal(t, ErrorBCDOverflow, err)
+	})
+}
@@ -1,5 +1,9 @@
 package bitbuffer
 
+import (
+	"io"
+)
+
 func (bb *BitBuffer) ReadByte() (byte, error) {
 	return bb.ReadBits(8)
 }
@@ -13,27 +17,22 @@ func (bb *BitBuffer) ReadBits(bitCount int) (byte, error) {
 		return 0, ErrorTooManyBitsInOperation
 	}
 
-	if bb.offset == 0 && bitCount == 8 {
-		return bb.buf.ReadByte()
+	if bb.readPos+bitCount > len(bb.data)*8 {
+		return 0, io.EOF
+	}
+
+	if bb.readPos%8 == 0 && bitCount == 8 {
+		b := bb.data[bb.readPos/8]
+		bb.readPos += 8
+		return b, nil
 	}
 
 	retVal := byte(0)
 
 	for i := 0; i < bitCount; i++ {
-		if bb.offset == 0 {
-			unhandled, err := bb.buf.ReadByte()
-
-			if err != nil {
-				return 0, err
-			}
-
-			bb.unhandled = unhandled
-			bb.offset = 8
-		}
-
-		bit := bb.unhandled&0x80 == 0x80
-		bb.unhandled <<= 1
-		bb.offset--
+		current := bb.data[bb.readPos/8]
+		bit := current&(0x80>>(bb.readPos%8)) != 0
+		bb.readPos++
 
 		retVal <<= 1
 
@@ -51,7 +50,8 @@ func (bb *BitBuffer) WriteBits(bits byte, bitCount int) error {
 	}
 
 	if bb.offset == 0 && bitCount == 8 {
-		return bb.buf.WriteByte(bits)
+		bb.data = append(bb.data, bits)
+		return nil
 	}
 
 	mask := byte(1 << (bitCount - 1))
@@ -69,7 +69,7 @@ func (bb *BitBuffer) WriteBits(bits byte, bitCount int) error {
 		bb.offset++
 
 		if bb.offset == 8 {
-			_ = bb.buf.WriteByte(bb.unhandled)
+			bb.data = append(bb.data, bb.unhandled)
 			bb.unhandled = 0
 			bb.offset = 0
 		}
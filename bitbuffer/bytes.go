@@ -0,0 +1,99 @@
+package bitbuffer
+
+import (
+	"io"
+)
+
+// ReadBytes reads n bytes from the buffer, which need not be byte aligned. If fewer than n bytes remain an error is
+// returned as with ReadFull, before anything is allocated, so n may safely come from untrusted data.
+func (bb *BitBuffer) ReadBytes(n int) ([]byte, error) {
+	if err := bb.available(n); err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, n)
+
+	if err := bb.ReadFull(data); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// ReadFull fills p with bytes read from the buffer, which need not be byte aligned. If there are not enough bytes
+// remaining io.ErrUnexpectedEOF is returned, or io.EOF if the buffer is empty, and nothing is read.
+func (bb *BitBuffer) ReadFull(p []byte) error {
+	if len(p) == 0 {
+		return nil
+	}
+
+	if err := bb.available(len(p)); err != nil {
+		return err
+	}
+
+	start := bb.readPos / 8
+	shift := uint(bb.readPos % 8)
+
+	if shift == 0 {
+		copy(p, bb.data[start:])
+	} else {
+		for i := range p {
+			p[i] = bb.data[start+i]<<shift | bb.data[start+i+1]>>(8-shift)
+		}
+	}
+
+	bb.readPos += len(p) * 8
+
+	return nil
+}
+
+// available returns io.ErrUnexpectedEOF if n is negative or more than the whole bytes remaining, or io.EOF if there
+// are none remaining.
+func (bb *BitBuffer) available(n int) error {
+	switch {
+	case n == 0:
+		return nil
+	case n < 0:
+		return io.ErrUnexpectedEOF
+	case bb.Remaining() < 8:
+		return io.EOF
+	case n > bb.Remaining()/8:
+		return io.ErrUnexpectedEOF
+	}
+
+	return nil
+}
+
+// WriteBytes writes all of p to the buffer, which need not be byte aligned.
+func (bb *BitBuffer) WriteBytes(p []byte) error {
+	if bb.offset == 0 {
+		bb.data = append(bb.data, p...)
+		return nil
+	}
+
+	shift := uint(bb.offset)
+
+	for _, b := range p {
+		bb.data = append(bb.data, bb.unhandled<<(8-shift)|b>>shift)
+		bb.unhandled = b & (1<<shift - 1)
+	}
+
+	return nil
+}
+
+// ReadBytesShared reads n bytes from the buffer. If the buffer is byte aligned the returned slice shares memory with
+// the buffer, with its capacity limited so that appending to it will not overwrite the buffer, otherwise it is a copy.
+func (bb *BitBuffer) ReadBytesShared(n int) ([]byte, error) {
+	if bb.readPos%8 != 0 {
+		return bb.ReadBytes(n)
+	}
+
+	if err := bb.available(n); err != nil {
+		return nil, err
+	}
+
+	start := bb.readPos / 8
+	bb.readPos += n * 8
+
+	return bb.data[start : start+n : start+n], nil
+}
@@ -0,0 +1,142 @@
+package bitbuffer
+
+import (
+	"io"
+	"math"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Bytes(t *testing.T) {
+	t.Run("writing bytes when aligned", func(t *testing.T) {
+		bb := NewBitBuffer()
+
+		err := bb.WriteBytes([]byte{0x01, 0x02, 0x03})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x01, 0x02, 0x03}, bb.Bytes())
+	})
+
+	t.Run("writing bytes when not aligned", func(t *testing.T) {
+		bb := NewBitBuffer()
+
+		_ = bb.WriteBits(0x05, 3)
+		err := bb.WriteBytes([]byte{0xff, 0x00})
+		_ = bb.WriteBits(0x0a, 5)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0b10111111, 0b11100000, 0b00001010}, bb.Bytes())
+	})
+
+	t.Run("reading bytes when aligned", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0x01, 0x02, 0x03})
+
+		actualBytes, err := bb.ReadBytes(2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x01, 0x02}, actualBytes)
+		assert.Equal(t, 16, bb.Position())
+	})
+
+	t.Run("reading bytes when not aligned", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0b10111111, 0b11100000, 0b00001010})
+
+		_, _ = bb.ReadBits(3)
+
+		actualBytes, err := bb.ReadBytes(2)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xff, 0x00}, actualBytes)
+
+		remaining, err := bb.ReadBits(5)
+		assert.NoError(t, err)
+		assert.Equal(t, byte(0x0a), remaining)
+	})
+
+	t.Run("reading returned bytes does not alias the buffer", func(t *testing.T) {
+		data := []byte{0x01, 0x02}
+		bb := NewBitBufferFromBytes(data)
+
+		actualBytes, _ := bb.ReadBytes(2)
+		actualBytes[0] = 0xff
+
+		assert.Equal(t, byte(0x01), data[0])
+	})
+
+	t.Run("reading more bytes than remain errors without consuming", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0x01, 0x02})
+
+		err := bb.ReadFull(make([]byte, 3))
+		assert.Equal(t, io.ErrUnexpectedEOF, err)
+		assert.Equal(t, 0, bb.Position())
+
+		_ = bb.SeekBits(16)
+
+		err = bb.ReadFull(make([]byte, 1))
+		assert.Equal(t, io.EOF, err)
+	})
+
+	t.Run("reading more bytes than remain errors before allocating", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0x01, 0x02})
+
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+
+		_, err := bb.ReadBytes(math.MaxInt32)
+		assert.Equal(t, io.ErrUnexpectedEOF, err)
+
+		runtime.ReadMemStats(&after)
+		assert.Less(t, after.TotalAlloc-before.TotalAlloc, uint64(1<<20))
+
+		_, err = bb.ReadBytes(-1)
+		assert.Equal(t, io.ErrUnexpectedEOF, err)
+		assert.Equal(t, 0, bb.Position())
+	})
+
+	t.Run("reading shared bytes when aligned aliases the buffer", func(t *testing.T) {
+		data := []byte{0x01, 0x02, 0x03}
+		bb := NewBitBufferFromBytes(data)
+
+		actualBytes, err := bb.ReadBytesShared(2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x01, 0x02}, actualBytes)
+		assert.Equal(t, 2, cap(actualBytes))
+
+		data[0] = 0xff
+		assert.Equal(t, byte(0xff), actualBytes[0])
+	})
+
+	t.Run("reading shared bytes when not aligned copies", func(t *testing.T) {
+		data := []byte{0x0f, 0xf0}
+		bb := NewBitBufferFromBytes(data)
+
+		_, _ = bb.ReadBits(4)
+
+		actualBytes, err := bb.ReadBytesShared(1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xff}, actualBytes)
+
+		data[0] = 0x00
+		assert.Equal(t, byte(0xff), actualBytes[0])
+	})
+
+	t.Run("reading more shared bytes than remain errors", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0x01})
+
+		_, err := bb.ReadBytesShared(2)
+
+		assert.Equal(t, io.ErrUnexpectedEOF, err)
+		assert.Equal(t, 0, bb.Position())
+
+		_, err = bb.ReadBytesShared(-1)
+		assert.Equal(t, io.ErrUnexpectedEOF, err)
+
+		allocs := testing.AllocsPerRun(10, func() {
+			_, _ = bb.ReadBytesShared(math.MaxInt32)
+		})
+		assert.Equal(t, float64(0), allocs)
+	})
+}
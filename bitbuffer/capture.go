@@ -0,0 +1,44 @@
+package bitbuffer
+
+import (
+	"errors"
+)
+
+var ErrorNotByteAligned = errors.New("bit buffer is not byte aligned")
+
+// Capture records the bytes read from or written to a BitBuffer, from when it is started until it is stopped.
+// Captures may overlap, and see the values of Reservations filled while they are active.
+type Capture struct {
+	bb         *BitBuffer
+	readStart  int
+	writeStart int
+}
+
+// Capture starts recording bytes, the buffer must be byte aligned.
+func (bb *BitBuffer) Capture() (*Capture, error) {
+	if !bb.aligned() {
+		return nil, ErrorNotByteAligned
+	}
+
+	return &Capture{bb: bb, readStart: bb.readPos, writeStart: len(bb.data)}, nil
+}
+
+// Stop returns the bytes read since the capture started or, if none were read, the bytes written. The buffer must be
+// byte aligned. The returned slice shares memory with the buffer.
+func (c *Capture) Stop() ([]byte, error) {
+	bb := c.bb
+
+	if !bb.aligned() {
+		return nil, ErrorNotByteAligned
+	}
+
+	if bb.readPos > c.readStart {
+		return bb.data[c.readStart/8 : bb.readPos/8], nil
+	}
+
+	return bb.data[c.writeStart:], nil
+}
+
+func (bb *BitBuffer) aligned() bool {
+	return bb.offset == 0 && bb.readPos%8 == 0
+}
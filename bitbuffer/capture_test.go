@@ -0,0 +1,69 @@
+package bitbuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Capture(t *testing.T) {
+	t.Run("capturing written bytes", func(t *testing.T) {
+		bb := NewBitBuffer()
+
+		_ = bb.WriteByte(0x01)
+
+		capture, err := bb.Capture()
+		assert.NoError(t, err)
+
+		_ = bb.WriteByte(0x02)
+		_ = bb.WriteBits(0x03, 4)
+		_ = bb.WriteBits(0x04, 4)
+
+		actualBytes, err := capture.Stop()
+		assert.NoError(t, err)
+
+		_ = bb.WriteByte(0x05)
+
+		assert.Equal(t, []byte{0x02, 0x34}, actualBytes)
+	})
+
+	t.Run("capturing read bytes with overlapping captures", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0x01, 0x02, 0x03})
+
+		outer, _ := bb.Capture()
+		_, _ = bb.ReadByte()
+
+		inner, _ := bb.Capture()
+		_, _ = bb.ReadByte()
+
+		innerBytes, err := inner.Stop()
+		assert.NoError(t, err)
+
+		_, _ = bb.ReadByte()
+
+		outerBytes, err := outer.Stop()
+		assert.NoError(t, err)
+
+		assert.Equal(t, []byte{0x02}, innerBytes)
+		assert.Equal(t, []byte{0x01, 0x02, 0x03}, outerBytes)
+	})
+
+	t.Run("capturing errors if not byte aligned", func(t *testing.T) {
+		bb := NewBitBuffer()
+
+		_ = bb.WriteBits(0x01, 4)
+
+		_, err := bb.Capture()
+		assert.Equal(t, ErrorNotByteAligned, err)
+
+		_ = bb.WriteBits(0x01, 4)
+
+		capture, err := bb.Capture()
+		assert.NoError(t, err)
+
+		_ = bb.WriteBits(0x01, 4)
+
+		_, err = capture.Stop()
+		assert.Equal(t, ErrorNotByteAligned, err)
+	})
+}
@@ -52,7 +52,7 @@ func (bb *BitBuffer) ReadInt(endian Endian, length int) (int64, error) {
 		for i := 0; i < bytes; i++ {
 			b, err := bb.ReadByte()
 			if err != nil {
-				return 0, nil
+				return 0, err
 			}
 
 			shiftOffset := (bytes - i - 1) * 8
@@ -70,7 +70,7 @@ func (bb *BitBuffer) ReadInt(endian Endian, length int) (int64, error) {
 		for i := 0; i < bytes; i++ {
 			b, err := bb.ReadByte()
 			if err != nil {
-				return 0, nil
+				return 0, err
 			}
 
 			shiftOffset := i * 8
@@ -94,6 +94,17 @@ func Test_ReadInt(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, expectedValue, actualValue)
 	})
+	t.Run("an error is thrown reading past the end of the buffer", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0x09})
+
+		_, err := bb.ReadInt(LittleEndian, 16)
+		assert.Error(t, err)
+
+		bb = NewBitBufferFromBytes([]byte{0x09})
+
+		_, err = bb.ReadInt(BigEndian, 16)
+		assert.Error(t, err)
+	})
 }
 
 func Test_WriteInt(t *testing.T) {
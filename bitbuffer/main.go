@@ -1,7 +1,6 @@
 package bitbuffer
 
 import (
-	"bytes"
 	"errors"
 )
 
@@ -10,19 +9,18 @@ const maxBitOperations int = 8
 var ErrorTooManyBitsInOperation = errors.New("bit buffer can only perform operations on 8 or fewer bits")
 
 func NewBitBuffer() *BitBuffer {
-	return &BitBuffer{
-		buf: &bytes.Buffer{},
-	}
+	return &BitBuffer{}
 }
 
 func NewBitBufferFromBytes(data []byte) *BitBuffer {
 	return &BitBuffer{
-		buf: bytes.NewBuffer(data),
+		data: data,
 	}
 }
 
 type BitBuffer struct {
-	buf       *bytes.Buffer
+	data      []byte
+	readPos   int
 	unhandled byte
 	offset    uint8
 }
@@ -31,5 +29,19 @@ func (bb *BitBuffer) Bytes() []byte {
 	if bb.offset != 0 {
 		_ = bb.WriteBits(0, int(8-bb.offset))
 	}
-	return bb.buf.Bytes()
+	return bb.data[bb.readPos/8:]
+}
+
+// Reset empties the buffer while retaining its capacity, so that it can be reused, such as from a sync.Pool.
+func (bb *BitBuffer) Reset() {
+	bb.data = bb.data[:0]
+	bb.readPos = 0
+	bb.unhandled = 0
+	bb.offset = 0
+}
+
+// ResetBytes empties the buffer and sets it to read from data, as if it had been created by NewBitBufferFromBytes.
+func (bb *BitBuffer) ResetBytes(data []byte) {
+	bb.Reset()
+	bb.data = data
 }
@@ -0,0 +1,42 @@
+package bitbuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Reset(t *testing.T) {
+	t.Run("resetting empties the buffer and retains its capacity", func(t *testing.T) {
+		bb := NewBitBuffer()
+
+		_ = bb.WriteByte(0x01)
+		_ = bb.WriteBits(0x01, 3)
+		_, _ = bb.ReadByte()
+
+		capacity := cap(bb.data)
+
+		bb.Reset()
+
+		assert.Equal(t, 0, bb.Len())
+		assert.Equal(t, 0, bb.Position())
+		assert.Equal(t, capacity, cap(bb.data))
+
+		_ = bb.WriteByte(0x02)
+		assert.Equal(t, []byte{0x02}, bb.Bytes())
+	})
+
+	t.Run("resetting to bytes reads from them", func(t *testing.T) {
+		bb := NewBitBuffer()
+
+		_ = bb.WriteByte(0x01)
+
+		bb.ResetBytes([]byte{0x02, 0x03})
+
+		actualByte, err := bb.ReadByte()
+
+		assert.NoError(t, err)
+		assert.Equal(t, byte(0x02), actualByte)
+		assert.Equal(t, 16, bb.Len())
+	})
+}
@@ -0,0 +1,57 @@
+package bitbuffer
+
+// Mark is a saved position of a BitBuffer, including any partially read or written byte.
+type Mark struct {
+	bb        *BitBuffer
+	readPos   int
+	length    int
+	unhandled byte
+	offset    uint8
+}
+
+// Mark saves the current position of the buffer, so that reads and writes made after it can be undone with Reset.
+func (bb *BitBuffer) Mark() Mark {
+	return Mark{
+		bb:        bb,
+		readPos:   bb.readPos,
+		length:    len(bb.data),
+		unhandled: bb.unhandled,
+		offset:    bb.offset,
+	}
+}
+
+// Reset restores the buffer to the position it was marked at, undoing any reads and discarding any writes made since.
+// A mark can be reset to more than once.
+func (m Mark) Reset() {
+	bb := m.bb
+
+	bb.readPos = m.readPos
+	bb.data = bb.data[:m.length]
+	bb.unhandled = m.unhandled
+	bb.offset = m.offset
+}
+
+func (bb *BitBuffer) PeekBits(bitCount int) (byte, error) {
+	m := bb.Mark()
+	defer m.Reset()
+
+	return bb.ReadBits(bitCount)
+}
+
+func (bb *BitBuffer) PeekByte() (byte, error) {
+	return bb.PeekBits(8)
+}
+
+func (bb *BitBuffer) PeekUint(endian Endian, length int) (uint64, error) {
+	m := bb.Mark()
+	defer m.Reset()
+
+	return bb.ReadUint(endian, length)
+}
+
+func (bb *BitBuffer) PeekInt(endian Endian, length int) (int64, error) {
+	m := bb.Mark()
+	defer m.Reset()
+
+	return bb.ReadInt(endian, length)
+}
@@ -0,0 +1,118 @@
+package bitbuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Mark(t *testing.T) {
+	t.Run("resetting to a mark undoes reads", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0x01, 0x02, 0x03})
+
+		_, _ = bb.ReadByte()
+
+		m := bb.Mark()
+
+		_, _ = bb.ReadByte()
+		_, _ = bb.ReadByte()
+
+		m.Reset()
+
+		actualValue, err := bb.ReadByte()
+
+		assert.NoError(t, err)
+		assert.Equal(t, byte(0x02), actualValue)
+	})
+
+	t.Run("resetting to a mark within a partially read byte", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0b10110011, 0xff})
+
+		_, _ = bb.ReadBits(3)
+
+		m := bb.Mark()
+
+		first, _ := bb.ReadBits(7)
+
+		m.Reset()
+
+		second, err := bb.ReadBits(7)
+
+		assert.NoError(t, err)
+		assert.Equal(t, byte(0b1001111), first)
+		assert.Equal(t, first, second)
+	})
+
+	t.Run("resetting to a mark discards writes", func(t *testing.T) {
+		bb := NewBitBuffer()
+
+		_ = bb.WriteBits(0b101, 3)
+
+		m := bb.Mark()
+
+		_ = bb.WriteByte(0xff)
+		_ = bb.WriteByte(0xff)
+
+		m.Reset()
+
+		_ = bb.WriteBits(0b00001, 5)
+
+		assert.Equal(t, []byte{0b10100001}, bb.Bytes())
+	})
+
+	t.Run("resetting to a mark trims active captures", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0x01, 0x02, 0x03})
+
+		c, _ := bb.Capture()
+
+		_, _ = bb.ReadByte()
+
+		m := bb.Mark()
+
+		_, _ = bb.ReadByte()
+
+		m.Reset()
+
+		actualBytes, err := c.Stop()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x01}, actualBytes)
+	})
+}
+
+func Test_Peek(t *testing.T) {
+	t.Run("peeking does not consume bits", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0xab, 0xcd})
+
+		bits, err := bb.PeekBits(4)
+		assert.NoError(t, err)
+		assert.Equal(t, byte(0x0a), bits)
+
+		b, err := bb.PeekByte()
+		assert.NoError(t, err)
+		assert.Equal(t, byte(0xab), b)
+
+		u, err := bb.PeekUint(BigEndian, 16)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(0xabcd), u)
+
+		i, err := bb.PeekInt(BigEndian, 8)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(-0x55), i)
+
+		actualValue, err := bb.ReadUint(LittleEndian, 16)
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(0xcdab), actualValue)
+	})
+
+	t.Run("peeking past the end of the buffer errors without consuming", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0xab})
+
+		_, err := bb.PeekUint(LittleEndian, 16)
+		assert.Error(t, err)
+
+		actualValue, err := bb.ReadByte()
+		assert.NoError(t, err)
+		assert.Equal(t, byte(0xab), actualValue)
+	})
+}
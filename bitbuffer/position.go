@@ -0,0 +1,66 @@
+package bitbuffer
+
+import (
+	"errors"
+)
+
+var ErrorSeekOutOfRange = errors.New("seek position is outside of the buffer")
+
+// Position returns the number of bits which have been read from the start of the buffer.
+func (bb *BitBuffer) Position() int {
+	return bb.readPos
+}
+
+// Len returns the number of bits held by the buffer, including those already read and any partially written byte.
+func (bb *BitBuffer) Len() int {
+	return len(bb.data)*8 + int(bb.offset)
+}
+
+// Remaining returns the number of bits which are available to be read.
+func (bb *BitBuffer) Remaining() int {
+	return len(bb.data)*8 - bb.readPos
+}
+
+// SeekBits moves the read position to an absolute number of bits from the start of the buffer.
+func (bb *BitBuffer) SeekBits(position int) error {
+	if position < 0 || position > len(bb.data)*8 {
+		return ErrorSeekOutOfRange
+	}
+
+	bb.readPos = position
+
+	return nil
+}
+
+// BitsBetween returns a copy of the bits between two positions from the start of the buffer, packed from the most
+// significant bit of the first byte, with any unused bits of the final byte set to zero. The read position is unchanged.
+func (bb *BitBuffer) BitsBetween(start int, end int) ([]byte, error) {
+	if start < 0 || end < start || end > len(bb.data)*8 {
+		return nil, ErrorSeekOutOfRange
+	}
+
+	src := NewBitBufferFromBytes(bb.data)
+	src.readPos = start
+
+	dst := NewBitBuffer()
+
+	for remaining := end - start; remaining > 0; {
+		bitCount := remaining
+		if bitCount > maxBitOperations {
+			bitCount = maxBitOperations
+		}
+
+		bits, err := src.ReadBits(bitCount)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := dst.WriteBits(bits, bitCount); err != nil {
+			return nil, err
+		}
+
+		remaining -= bitCount
+	}
+
+	return dst.Bytes(), nil
+}
@@ -0,0 +1,94 @@
+package bitbuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Position(t *testing.T) {
+	t.Run("position and remaining track bits read", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0x01, 0x02, 0x03})
+
+		assert.Equal(t, 0, bb.Position())
+		assert.Equal(t, 24, bb.Remaining())
+		assert.Equal(t, 24, bb.Len())
+
+		_, _ = bb.ReadBits(3)
+		_, _ = bb.ReadByte()
+
+		assert.Equal(t, 11, bb.Position())
+		assert.Equal(t, 13, bb.Remaining())
+		assert.Equal(t, 24, bb.Len())
+	})
+
+	t.Run("len tracks bits written including partial bytes", func(t *testing.T) {
+		bb := NewBitBuffer()
+
+		_ = bb.WriteByte(0xff)
+		_ = bb.WriteBits(0x01, 3)
+
+		assert.Equal(t, 11, bb.Len())
+		assert.Equal(t, 0, bb.Position())
+	})
+
+	t.Run("seeking moves the read position to any bit", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0x0f, 0xf0})
+
+		err := bb.SeekBits(4)
+		assert.NoError(t, err)
+
+		actualValue, err := bb.ReadByte()
+
+		assert.NoError(t, err)
+		assert.Equal(t, byte(0xff), actualValue)
+
+		err = bb.SeekBits(0)
+		assert.NoError(t, err)
+
+		actualValue, err = bb.ReadByte()
+
+		assert.NoError(t, err)
+		assert.Equal(t, byte(0x0f), actualValue)
+	})
+
+	t.Run("seeking outside of the buffer errors", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0x0f, 0xf0})
+
+		assert.Equal(t, ErrorSeekOutOfRange, bb.SeekBits(-1))
+		assert.Equal(t, ErrorSeekOutOfRange, bb.SeekBits(17))
+		assert.NoError(t, bb.SeekBits(16))
+		assert.Equal(t, 0, bb.Remaining())
+	})
+
+	t.Run("bits between positions are copied from the most significant bit", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0x0f, 0xf0, 0xaa})
+		_, _ = bb.ReadBits(3)
+
+		actualValue, err := bb.BitsBetween(4, 16)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xff, 0x00}, actualValue)
+		assert.Equal(t, 3, bb.Position())
+
+		actualValue, err = bb.BitsBetween(16, 19)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xa0}, actualValue)
+
+		actualValue, err = bb.BitsBetween(8, 8)
+
+		assert.NoError(t, err)
+		assert.Empty(t, actualValue)
+	})
+
+	t.Run("bits between positions outside of the buffer errors", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0x0f})
+
+		_, err := bb.BitsBetween(4, 9)
+		assert.Equal(t, ErrorSeekOutOfRange, err)
+
+		_, err = bb.BitsBetween(4, 3)
+		assert.Equal(t, ErrorSeekOutOfRange, err)
+	})
+}
@@ -0,0 +1,94 @@
+package bitbuffer
+
+import (
+	"errors"
+)
+
+var ErrorReservationSize = errors.New("value written to reservation does not match reserved size")
+
+// Reservation is space reserved in a BitBuffer being written, which can be filled once its value is known.
+type Reservation struct {
+	bb       *BitBuffer
+	position int
+	length   int
+}
+
+// Reserve writes the requested number of zero bits to the buffer, returning a Reservation which can later overwrite
+// them. The buffer must not be read from until the reservation is filled.
+func (bb *BitBuffer) Reserve(bits int) (*Reservation, error) {
+	r := &Reservation{bb: bb, position: bb.Len(), length: bits}
+
+	for remaining := bits; remaining > 0; remaining -= maxBitOperations {
+		count := remaining
+
+		if count > maxBitOperations {
+			count = maxBitOperations
+		}
+
+		if err := bb.WriteBits(0, count); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+func (r *Reservation) Bits() int {
+	return r.length
+}
+
+// WriteUint fills the reservation with an unsigned integer, as if it had been written with BitBuffer.WriteUint.
+func (r *Reservation) WriteUint(value uint64, endian Endian) error {
+	return r.Fill(func(bb *BitBuffer) error {
+		return bb.WriteUint(value, endian, r.length)
+	})
+}
+
+// Fill fills the reservation with whatever the provided function writes to the BitBuffer it is given, which must be
+// exactly the reserved number of bits.
+func (r *Reservation) Fill(fn func(*BitBuffer) error) error {
+	tmp := NewBitBuffer()
+
+	if err := fn(tmp); err != nil {
+		return err
+	}
+
+	if tmp.Len() != r.length {
+		return ErrorReservationSize
+	}
+
+	data := tmp.Bytes()
+
+	for i := 0; i < r.length; i++ {
+		bit := data[i/8]&(0x80>>(i%8)) != 0
+		r.bb.setBit(r.position+i, bit)
+	}
+
+	return nil
+}
+
+func (bb *BitBuffer) setBit(position int, bit bool) {
+	byteIndex := position / 8
+	bitIndex := position % 8
+
+	if byteIndex < len(bb.data) {
+		mask := byte(0x80) >> bitIndex
+		data := bb.data
+
+		if bit {
+			data[byteIndex] |= mask
+		} else {
+			data[byteIndex] &^= mask
+		}
+
+		return
+	}
+
+	mask := byte(1) << (int(bb.offset) - 1 - bitIndex)
+
+	if bit {
+		bb.unhandled |= mask
+	} else {
+		bb.unhandled &^= mask
+	}
+}
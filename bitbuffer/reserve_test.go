@@ -0,0 +1,98 @@
+package bitbuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Reserve(t *testing.T) {
+	t.Run("reserving and filling byte aligned space", func(t *testing.T) {
+		bb := NewBitBuffer()
+
+		_ = bb.WriteByte(0x01)
+
+		r, err := bb.Reserve(16)
+		assert.NoError(t, err)
+		assert.Equal(t, 16, r.Bits())
+
+		_ = bb.WriteByte(0x02)
+
+		err = r.WriteUint(0x1234, BigEndian)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []byte{0x01, 0x12, 0x34, 0x02}, bb.Bytes())
+	})
+
+	t.Run("reserving and filling non byte aligned space", func(t *testing.T) {
+		bb := NewBitBuffer()
+
+		_ = bb.WriteBits(0x01, 2)
+
+		r, err := bb.Reserve(5)
+		assert.NoError(t, err)
+
+		_ = bb.WriteBits(0x01, 1)
+		_ = bb.WriteByte(0xff)
+
+		err = r.WriteUint(0x1f, LittleEndian)
+		assert.NoError(t, err)
+
+		assert.Equal(t, []byte{0b01111111, 0xff}, bb.Bytes())
+	})
+
+	t.Run("filling a reservation in the partially written byte", func(t *testing.T) {
+		bb := NewBitBuffer()
+
+		_ = bb.WriteBits(0x00, 1)
+
+		r, _ := bb.Reserve(3)
+
+		_ = bb.WriteBits(0x01, 1)
+
+		err := r.WriteUint(0x05, LittleEndian)
+		assert.NoError(t, err)
+
+		_ = bb.WriteBits(0x00, 3)
+
+		assert.Equal(t, []byte{0b01011000}, bb.Bytes())
+	})
+
+	t.Run("filling a reservation updates active captures", func(t *testing.T) {
+		bb := NewBitBuffer()
+
+		_ = bb.WriteByte(0x01)
+
+		c, _ := bb.Capture()
+		r, _ := bb.Reserve(8)
+
+		_ = bb.WriteByte(0x02)
+		_ = r.WriteUint(0xaa, LittleEndian)
+
+		actualBytes, err := c.Stop()
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xaa, 0x02}, actualBytes)
+	})
+
+	t.Run("filling a reservation with too large a value errors", func(t *testing.T) {
+		bb := NewBitBuffer()
+
+		r, _ := bb.Reserve(4)
+
+		err := r.WriteUint(0x10, LittleEndian)
+		assert.Error(t, err)
+	})
+
+	t.Run("filling a reservation with the wrong number of bits errors", func(t *testing.T) {
+		bb := NewBitBuffer()
+
+		r, _ := bb.Reserve(8)
+
+		err := r.Fill(func(bb *BitBuffer) error {
+			return bb.WriteBits(0x01, 4)
+		})
+
+		assert.Equal(t, ErrorReservationSize, err)
+	})
+}
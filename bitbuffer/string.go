@@ -46,6 +46,32 @@ func (bb *BitBuffer) WriteStringLengthPrefixed(data string, endian Endian, lengt
 	return bb.writeString(data)
 }
 
+func (bb *BitBuffer) WriteStringLengthPrefixedNullable(data *string, endian Endian, length int) error {
+	invalidLength := InvalidLengthPrefix(length)
+
+	if data == nil {
+		return bb.WriteUint(invalidLength, endian, length)
+	}
+
+	if uint64(len(*data)) >= invalidLength {
+		return ErrorStringTooLarge
+	}
+
+	return bb.WriteStringLengthPrefixed(*data, endian, length)
+}
+
+func (bb *BitBuffer) WriteStringVarintPrefixed(data string) error {
+	if err := bb.WriteUvarint(uint64(len(data))); err != nil {
+		return err
+	}
+
+	return bb.writeString(data)
+}
+
+func InvalidLengthPrefix(length int) uint64 {
+	return uint64(math.Pow(2, float64(length)) - 1)
+}
+
 func (bb *BitBuffer) ReadStringNullTerminated(paddedLength int) (string, error) {
 	sb := strings.Builder{}
 
@@ -90,33 +116,54 @@ func (bb *BitBuffer) ReadStringNullTerminated(paddedLength int) (string, error)
 }
 
 func (bb *BitBuffer) ReadStringLengthPrefixed(endian Endian, length int) (string, error) {
-	sb := strings.Builder{}
-
 	stringLength, err := bb.ReadUint(endian, length)
 	if err != nil {
 		return "", err
 	}
 
-	for i := 0; i < int(stringLength); i++ {
-		readByte, err := bb.ReadByte()
-		if err != nil {
-			return "", err
-		}
+	return bb.readString(int(stringLength))
+}
 
-		sb.WriteByte(readByte)
+func (bb *BitBuffer) ReadStringVarintPrefixed() (string, error) {
+	stringLength, err := bb.ReadUvarint()
+	if err != nil {
+		return "", err
 	}
 
-	return sb.String(), nil
+	if stringLength > math.MaxInt32 {
+		return "", ErrorStringTooLarge
+	}
+
+	return bb.readString(int(stringLength))
 }
 
-func (bb *BitBuffer) writeString(data string) error {
-	dataBytes := []byte(data)
+func (bb *BitBuffer) ReadStringLengthPrefixedNullable(endian Endian, length int) (*string, error) {
+	stringLength, err := bb.ReadUint(endian, length)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, b := range dataBytes {
-		if err := bb.WriteByte(b); err != nil {
-			return nil
-		}
+	if stringLength == InvalidLengthPrefix(length) {
+		return nil, nil
 	}
 
-	return nil
+	str, err := bb.readString(int(stringLength))
+	if err != nil {
+		return nil, err
+	}
+
+	return &str, nil
+}
+
+func (bb *BitBuffer) readString(stringLength int) (string, error) {
+	data, err := bb.ReadBytes(stringLength)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (bb *BitBuffer) writeString(data string) error {
+	return bb.WriteBytes([]byte(data))
 }
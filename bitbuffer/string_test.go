@@ -1,6 +1,7 @@
 package bitbuffer
 
 import (
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -154,6 +155,84 @@ func Test_String(t *testing.T) {
 		assert.Equal(t, expectedString, actualString)
 	})
 
+	t.Run("unmarshal length prefixed string, length beyond the data errors", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0xff, 0xff, 0xff, 0x7f, 'a'})
+
+		_, err := bb.ReadStringLengthPrefixed(LittleEndian, 32)
+
+		assert.Equal(t, io.ErrUnexpectedEOF, err)
+	})
+
+	t.Run("unmarshal length prefixed string, 64 bit length which overflows int errors", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 'a'})
+
+		_, err := bb.ReadStringLengthPrefixed(LittleEndian, 64)
+
+		assert.Equal(t, io.ErrUnexpectedEOF, err)
+	})
+
+	t.Run("unmarshal varint prefixed string, length beyond the data errors", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0xff, 0xff, 0xff, 0xff, 0x03, 'a'})
+
+		_, err := bb.ReadStringVarintPrefixed()
+
+		assert.Equal(t, io.ErrUnexpectedEOF, err)
+	})
+
+	t.Run("write nullable length prefixed string, nil writes invalid length", func(t *testing.T) {
+		bb := NewBitBuffer()
+
+		err := bb.WriteStringLengthPrefixedNullable(nil, LittleEndian, 16)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xff, 0xff}, bb.Bytes())
+	})
+
+	t.Run("write nullable length prefixed string, string of invalid length errors", func(t *testing.T) {
+		bb := NewBitBuffer()
+
+		data := "abc"
+		err := bb.WriteStringLengthPrefixedNullable(&data, LittleEndian, 2)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("unmarshal nullable length prefixed string, invalid length returns nil", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0xff})
+
+		actualValue, err := bb.ReadStringLengthPrefixedNullable(LittleEndian, 8)
+
+		assert.NoError(t, err)
+		assert.Nil(t, actualValue)
+	})
+
+	t.Run("unmarshal nullable length prefixed string, empty string returns empty", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0x00})
+
+		actualValue, err := bb.ReadStringLengthPrefixedNullable(LittleEndian, 8)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "", *actualValue)
+	})
+
+	t.Run("write varint prefixed string", func(t *testing.T) {
+		bb := NewBitBuffer()
+
+		err := bb.WriteStringVarintPrefixed("Hi")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x02, 0x48, 0x69}, bb.Bytes())
+	})
+
+	t.Run("unmarshal varint prefixed string", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0x02, 0x48, 0x69})
+
+		actualValue, err := bb.ReadStringVarintPrefixed()
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Hi", actualValue)
+	})
+
 	t.Run("writing a string", func(t *testing.T) {
 		bb := NewBitBuffer()
 
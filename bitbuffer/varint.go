@@ -0,0 +1,69 @@
+package bitbuffer
+
+import (
+	"errors"
+	"io"
+)
+
+const maxVarintBytes = 10
+
+var ErrorVarintOverflow = errors.New("varint overflows a 64 bit integer")
+var ErrorVarintOverlong = errors.New("varint is not minimally encoded")
+
+func (bb *BitBuffer) WriteUvarint(value uint64) error {
+	for value >= 0x80 {
+		if err := bb.WriteByte(byte(value) | 0x80); err != nil {
+			return err
+		}
+
+		value >>= 7
+	}
+
+	return bb.WriteByte(byte(value))
+}
+
+// ReadUvarint reads an unsigned varint, returning io.EOF if the buffer is empty, or io.ErrUnexpectedEOF if it ends
+// after one or more bytes of the varint.
+func (bb *BitBuffer) ReadUvarint() (uint64, error) {
+	readValue := uint64(0)
+
+	for i := 0; i < maxVarintBytes; i++ {
+		b, err := bb.ReadByte()
+		if err != nil {
+			if i > 0 && errors.Is(err, io.EOF) {
+				return 0, io.ErrUnexpectedEOF
+			}
+
+			return 0, err
+		}
+
+		if i == maxVarintBytes-1 && b > 0x01 {
+			return 0, ErrorVarintOverflow
+		}
+
+		readValue |= uint64(b&0x7f) << (7 * i)
+
+		if b < 0x80 {
+			if b == 0 && i > 0 {
+				return 0, ErrorVarintOverlong
+			}
+
+			return readValue, nil
+		}
+	}
+
+	return 0, ErrorVarintOverflow
+}
+
+func (bb *BitBuffer) WriteVarint(value int64) error {
+	return bb.WriteUvarint(uint64(value<<1) ^ uint64(value>>63))
+}
+
+func (bb *BitBuffer) ReadVarint() (int64, error) {
+	readValue, err := bb.ReadUvarint()
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(readValue>>1) ^ -int64(readValue&1), nil
+}
@@ -0,0 +1,131 @@
+package bitbuffer
+
+import (
+	"io"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WriteUvarint(t *testing.T) {
+	t.Run("writing a single byte varint", func(t *testing.T) {
+		bb := NewBitBuffer()
+
+		err := bb.WriteUvarint(0x7f)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x7f}, bb.Bytes())
+	})
+
+	t.Run("writing a multi byte varint", func(t *testing.T) {
+		bb := NewBitBuffer()
+
+		err := bb.WriteUvarint(300)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xac, 0x02}, bb.Bytes())
+	})
+
+	t.Run("writing the maximum varint", func(t *testing.T) {
+		bb := NewBitBuffer()
+
+		err := bb.WriteUvarint(math.MaxUint64)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}, bb.Bytes())
+	})
+}
+
+func Test_ReadUvarint(t *testing.T) {
+	t.Run("reading a multi byte varint", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0xac, 0x02, 0xff})
+
+		actualValue, err := bb.ReadUvarint()
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(300), actualValue)
+	})
+
+	t.Run("reading the maximum varint", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01})
+
+		actualValue, err := bb.ReadUvarint()
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(math.MaxUint64), actualValue)
+	})
+
+	t.Run("reading a varint which overflows 64 bits errors", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x02})
+
+		_, err := bb.ReadUvarint()
+
+		assert.Equal(t, ErrorVarintOverflow, err)
+	})
+
+	t.Run("reading a varint longer than 10 bytes errors", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x00})
+
+		_, err := bb.ReadUvarint()
+
+		assert.Equal(t, ErrorVarintOverflow, err)
+	})
+
+	t.Run("reading an over long varint errors", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0x81, 0x00})
+
+		_, err := bb.ReadUvarint()
+
+		assert.Equal(t, ErrorVarintOverlong, err)
+	})
+
+	t.Run("reading a truncated varint errors with unexpected EOF", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{0x81})
+
+		_, err := bb.ReadUvarint()
+
+		assert.Equal(t, io.ErrUnexpectedEOF, err)
+
+		bb = NewBitBufferFromBytes([]byte{0xac})
+
+		_, err = bb.ReadVarint()
+
+		assert.Equal(t, io.ErrUnexpectedEOF, err)
+	})
+
+	t.Run("reading a varint from an empty buffer errors with EOF", func(t *testing.T) {
+		bb := NewBitBufferFromBytes([]byte{})
+
+		_, err := bb.ReadUvarint()
+
+		assert.Equal(t, io.EOF, err)
+	})
+}
+
+func Test_Varint(t *testing.T) {
+	t.Run("writing zigzag encoded varints", func(t *testing.T) {
+		bb := NewBitBuffer()
+
+		assert.NoError(t, bb.WriteVarint(0))
+		assert.NoError(t, bb.WriteVarint(-1))
+		assert.NoError(t, bb.WriteVarint(1))
+		assert.NoError(t, bb.WriteVarint(-64))
+		assert.NoError(t, bb.WriteVarint(64))
+
+		assert.Equal(t, []byte{0x00, 0x01, 0x02, 0x7f, 0x80, 0x01}, bb.Bytes())
+	})
+
+	t.Run("reading zigzag encoded varints", func(t *testing.T) {
+		for _, expectedValue := range []int64{0, -1, 1, -64, 64, math.MinInt64, math.MaxInt64} {
+			bb := NewBitBuffer()
+
+			assert.NoError(t, bb.WriteVarint(expectedValue))
+
+			actualValue, err := bb.ReadVarint()
+
+			assert.NoError(t, err)
+			assert.Equal(t, expectedValue, actualValue)
+		}
+	})
+}
@@ -0,0 +1,209 @@
+package bytecodec
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/shimmeringbee/bytecodec/bitbuffer"
+)
+
+// Bitmap marks a struct as a bitmap, its bool fields are encoded as the bits of a single word. The marker requires a
+// bcbitmap tag with the word size in bits, and the word is written in the endian of a bcendian tag on the marker or the
+// struct defaults, little endian otherwise. Bool fields require a bcbit tag with their position, counting from the
+// least significant bit. A single unsigned integer field tagged `bcbit:"reserved"` preserves any bits which are not
+// mapped to a bool field.
+type Bitmap struct{}
+
+var bitmapType = reflect.TypeOf(Bitmap{})
+
+func isBitmapMarker(field reflect.StructField) bool {
+	return field.Type == bitmapType
+}
+
+type bitmapBit struct {
+	index    int
+	name     string
+	position uint8
+}
+
+type bitmapLayout struct {
+	tag      BitmapTag
+	bits     []bitmapBit
+	reserved int
+	mask     uint64
+}
+
+func findBitmapLayout(structType reflect.Type, defaults reflect.StructTag, lenient bool) (bitmapLayout, bool, error) {
+	layout := bitmapLayout{reserved: -1}
+	isBitmap := false
+
+	defaults = structDefaults(structType, defaults)
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		if isBitmapMarker(field) {
+			tag, err := tagBitmap(withDefaults(field.Tag, defaults), lenient)
+			if err != nil {
+				return bitmapLayout{}, true, err
+			}
+
+			layout.tag = tag
+			isBitmap = true
+		}
+	}
+
+	if !isBitmap {
+		return bitmapLayout{}, false, nil
+	}
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		if isBitmapMarker(field) || isDefaultsMarker(field) || isIgnored(field) {
+			continue
+		}
+
+		bitTag, err := tagBit(field.Tag)
+		if err != nil {
+			return bitmapLayout{}, true, err
+		}
+
+		switch {
+		case field.Type.Kind() == reflect.Bool && bitTag.Present && !bitTag.Reserved:
+			if bitTag.Position >= layout.tag.Size {
+				return bitmapLayout{}, true, fmt.Errorf("bitmap field '%s' bit %d does not fit in %d bits", field.Name, bitTag.Position, layout.tag.Size)
+			}
+
+			bit := uint64(1) << bitTag.Position
+
+			if layout.mask&bit != 0 {
+				return bitmapLayout{}, true, fmt.Errorf("bitmap field '%s' bit %d is used by another field", field.Name, bitTag.Position)
+			}
+
+			layout.mask |= bit
+			layout.bits = append(layout.bits, bitmapBit{index: i, name: field.Name, position: bitTag.Position})
+		case isUint(field.Type.Kind()) && bitTag.Reserved:
+			if layout.reserved >= 0 {
+				return bitmapLayout{}, true, fmt.Errorf("bitmap field '%s' is a second reserved field", field.Name)
+			}
+
+			if field.Type.Bits() < int(layout.tag.Size) {
+				return bitmapLayout{}, true, fmt.Errorf("bitmap field '%s' is too small to hold %d reserved bits", field.Name, layout.tag.Size)
+			}
+
+			layout.reserved = i
+		default:
+			return bitmapLayout{}, true, fmt.Errorf("%w: bitmap field '%s' must be a bool with a %s position or an unsigned integer with %s reserved", ErrUnsupportedType, field.Name, TagBit, TagBit)
+		}
+	}
+
+	sort.Slice(layout.bits, func(i, j int) bool { return layout.bits[i].position < layout.bits[j].position })
+
+	return layout, true, nil
+}
+
+func isUint(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+func (l bitmapLayout) word(structValue reflect.Value) uint64 {
+	word := uint64(0)
+
+	for _, bit := range l.bits {
+		if structValue.Field(bit.index).Bool() {
+			word |= 1 << bit.position
+		}
+	}
+
+	if l.reserved >= 0 {
+		word |= structValue.Field(l.reserved).Uint() &^ l.mask & allOnes(int(l.tag.Size))
+	}
+
+	return word
+}
+
+func (l bitmapLayout) setWord(structValue reflect.Value, word uint64) {
+	for _, bit := range l.bits {
+		structValue.Field(bit.index).SetBool(word&(1<<bit.position) != 0)
+	}
+
+	if l.reserved >= 0 {
+		structValue.Field(l.reserved).SetUint(word &^ l.mask)
+	}
+}
+
+// bitOffset returns the offset of a bit of the word from the start of the encoded bitmap.
+func (l bitmapLayout) bitOffset(position uint8) int {
+	p := int(position)
+
+	byteIndex := p / 8
+	if l.tag.Endian == bitbuffer.BigEndian {
+		byteIndex = int(l.tag.Size)/8 - 1 - byteIndex
+	}
+
+	return byteIndex*8 + 7 - p%8
+}
+
+func marshalBitmap(bb *bitbuffer.BitBuffer, layout bitmapLayout, structValue reflect.Value) error {
+	return bb.WriteUint(layout.word(structValue), layout.tag.Endian, int(layout.tag.Size))
+}
+
+func unmarshalBitmap(bb *bitbuffer.BitBuffer, layout bitmapLayout, structValue reflect.Value) error {
+	word, err := bb.ReadUint(layout.tag.Endian, int(layout.tag.Size))
+	if err != nil {
+		return err
+	}
+
+	layout.setWord(structValue, word)
+
+	return nil
+}
+
+// BitmapFlags returns the names of the bool fields which are set in a bitmap struct, in bit order. Set bits held by the
+// reserved field are named by their position, such as "bit7".
+func BitmapFlags(v interface{}) ([]string, error) {
+	structValue := reflect.Indirect(reflect.ValueOf(v))
+
+	if structValue.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: bitmap must be a struct", ErrUnsupportedType)
+	}
+
+	// The endian of the bitmap is not used, so a lenient bcendian does not matter.
+	layout, isBitmap, err := findBitmapLayout(structValue.Type(), "", true)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isBitmap {
+		return nil, fmt.Errorf("%w: struct '%v' is not a bitmap", ErrUnsupportedType, structValue.Type())
+	}
+
+	word := layout.word(structValue)
+	flags := []string{}
+
+	names := make(map[uint8]string, len(layout.bits))
+	for _, bit := range layout.bits {
+		names[bit.position] = bit.name
+	}
+
+	for position := uint8(0); position < layout.tag.Size; position++ {
+		if word&(1<<position) == 0 {
+			continue
+		}
+
+		if name, found := names[position]; found {
+			flags = append(flags, name)
+		} else {
+			flags = append(flags, fmt.Sprintf("bit%d", position))
+		}
+	}
+
+	return flags, nil
+}
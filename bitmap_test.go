@@ -0,0 +1,125 @@
+package bytecodec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type bitmapUnderTest struct {
+	_        Bitmap `bcbitmap:"16"`
+	OnOff    bool   `bcbit:"0"`
+	Level    bool   `bcbit:"1"`
+	Color    bool   `bcbit:"9"`
+	Reserved uint16 `bcbit:"reserved"`
+}
+
+func TestBitmap(t *testing.T) {
+	t.Run("verify bitmap is marshalled as little endian word", func(t *testing.T) {
+		type StructUnderTest struct {
+			Capabilities bitmapUnderTest
+			After        uint8
+		}
+
+		instance := &StructUnderTest{Capabilities: bitmapUnderTest{OnOff: true, Color: true}, After: 0xaa}
+		actualBytes, err := Marshal(instance)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x01, 0x02, 0xaa}, actualBytes)
+
+		actualStruct := &StructUnderTest{}
+		err = Unmarshal(actualBytes, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, instance, actualStruct)
+	})
+
+	t.Run("verify reserved bits are preserved", func(t *testing.T) {
+		actualStruct := &bitmapUnderTest{}
+		err := Unmarshal([]byte{0x83, 0x40}, actualStruct)
+
+		assert.NoError(t, err)
+		assert.True(t, actualStruct.OnOff)
+		assert.True(t, actualStruct.Level)
+		assert.False(t, actualStruct.Color)
+		assert.Equal(t, uint16(0x4080), actualStruct.Reserved)
+
+		actualBytes, err := Marshal(actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x83, 0x40}, actualBytes)
+	})
+
+	t.Run("verify reserved bits do not override named bits", func(t *testing.T) {
+		actualBytes, err := Marshal(&bitmapUnderTest{Reserved: 0xffff})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xfc, 0xfd}, actualBytes)
+	})
+
+	t.Run("verify bitmap honours big endian", func(t *testing.T) {
+		type BigEndianBitmap struct {
+			_     Bitmap `bcbitmap:"24" bcendian:"big"`
+			First bool   `bcbit:"0"`
+			Last  bool   `bcbit:"23"`
+		}
+
+		actualBytes, err := Marshal(&BigEndianBitmap{First: true, Last: true})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x80, 0x00, 0x01}, actualBytes)
+	})
+
+	t.Run("verify invalid layouts error", func(t *testing.T) {
+		type OutOfRange struct {
+			_    Bitmap `bcbitmap:"8"`
+			Flag bool   `bcbit:"8"`
+		}
+
+		_, err := Marshal(&OutOfRange{})
+		assert.Error(t, err)
+
+		type Duplicate struct {
+			_     Bitmap `bcbitmap:"8"`
+			Flag  bool   `bcbit:"1"`
+			Other bool   `bcbit:"1"`
+		}
+
+		_, err = Marshal(&Duplicate{})
+		assert.Error(t, err)
+
+		type Untagged struct {
+			_    Bitmap `bcbitmap:"8"`
+			Flag bool
+		}
+
+		_, err = Marshal(&Untagged{})
+		assert.True(t, errors.Is(err, ErrUnsupportedType))
+
+		type SmallReserved struct {
+			_        Bitmap `bcbitmap:"16"`
+			Reserved uint8  `bcbit:"reserved"`
+		}
+
+		_, err = Marshal(&SmallReserved{})
+		assert.Error(t, err)
+	})
+}
+
+func TestBitmapFlags(t *testing.T) {
+	t.Run("verify set flags are named in bit order", func(t *testing.T) {
+		flags, err := BitmapFlags(bitmapUnderTest{Color: true, OnOff: true, Reserved: 0x0400})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"OnOff", "Color", "bit10"}, flags)
+	})
+
+	t.Run("verify non bitmaps error", func(t *testing.T) {
+		_, err := BitmapFlags(struct{ Flag bool }{})
+		assert.True(t, errors.Is(err, ErrUnsupportedType))
+
+		_, err = BitmapFlags(uint8(0))
+		assert.True(t, errors.Is(err, ErrUnsupportedType))
+	})
+}
@@ -0,0 +1,35 @@
+package bytecodec
+
+import (
+	"reflect"
+)
+
+var bulkExcludedTags = []string{TagFieldWidth, TagScale, TagBCD}
+
+// isBulkBytes returns true if elements of the slice or array type can be read and written as plain bytes, rather than
+// individually.
+func isBulkBytes(ctx Context, collectionType reflect.Type, tags reflect.StructTag) bool {
+	elemType := collectionType.Elem()
+
+	if elemType.Kind() != reflect.Uint8 {
+		return false
+	}
+
+	if reflect.PtrTo(elemType).Implements(marshalerType) || reflect.PtrTo(elemType).Implements(unmarshalerType) {
+		return false
+	}
+
+	for _, tag := range bulkExcludedTags {
+		if _, tagPresent := tags.Lookup(tag); tagPresent {
+			return false
+		}
+	}
+
+	if ctx.options.strictEnums {
+		if _, found := lookupEnum(elemType); found {
+			return false
+		}
+	}
+
+	return true
+}
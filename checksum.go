@@ -0,0 +1,290 @@
+package bytecodec
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"reflect"
+	"sync"
+
+	"github.com/shimmeringbee/bytecodec/bitbuffer"
+)
+
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+type ChecksumError struct {
+	Field     string
+	Algorithm string
+	Expected  uint64
+	Actual    uint64
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("%v: field '%s' %s expected 0x%x, received 0x%x", ErrChecksumMismatch, e.Field, e.Algorithm, e.Expected, e.Actual)
+}
+
+func (e *ChecksumError) Unwrap() error {
+	return ErrChecksumMismatch
+}
+
+// ChecksumFunc computes a checksum over the bytes of a span of fields.
+type ChecksumFunc func(data []byte) uint64
+
+type checksumAlgorithm struct {
+	size int
+	sum  ChecksumFunc
+}
+
+var checksums = struct {
+	sync.RWMutex
+	algorithms map[string]checksumAlgorithm
+}{algorithms: map[string]checksumAlgorithm{
+	"crc8":        {size: 8, sum: crc8},
+	"crc16ccitt":  {size: 16, sum: crc16CCITT},
+	"crc16kermit": {size: 16, sum: crc16Kermit},
+	"crc16modbus": {size: 16, sum: crc16Modbus},
+	"crc32":       {size: 32, sum: crc32IEEE},
+	"xor":         {size: 8, sum: xor8},
+	"sum":         {size: 8, sum: twosComplementSum8},
+}}
+
+// RegisterChecksum registers a checksum algorithm for use in bcchecksum tags, the checksum is written with the
+// provided bit size. Registering an existing name replaces it.
+func RegisterChecksum(name string, size int, sum ChecksumFunc) error {
+	if size <= 0 || size > 64 || size%8 != 0 {
+		return fmt.Errorf("checksum '%s' size %d must be a multiple of 8 bits, up to 64", name, size)
+	}
+
+	checksums.Lock()
+	defer checksums.Unlock()
+
+	checksums.algorithms[name] = checksumAlgorithm{size: size, sum: sum}
+
+	return nil
+}
+
+func lookupChecksum(name string) (checksumAlgorithm, bool) {
+	checksums.RLock()
+	defer checksums.RUnlock()
+
+	algorithm, found := checksums.algorithms[name]
+	return algorithm, found
+}
+
+type checksumSpan struct {
+	tag       ChecksumTag
+	algorithm checksumAlgorithm
+	capture   *bitbuffer.Capture
+}
+
+// findChecksums returns the checksums of a struct keyed by field index, and the checksums to start capturing for at
+// each field index.
+func findChecksums(structType reflect.Type) (map[int]*checksumSpan, map[int][]*checksumSpan, error) {
+	var spans map[int]*checksumSpan
+	var starts map[int][]*checksumSpan
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		checksumTag, err := tagChecksum(field.Tag)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !checksumTag.Present {
+			continue
+		}
+
+		algorithm, found := lookupChecksum(checksumTag.Algorithm)
+		if !found {
+			return nil, nil, fmt.Errorf("field '%s' has unknown checksum algorithm '%s'", field.Name, checksumTag.Algorithm)
+		}
+
+		if !isUint(field.Type.Kind()) || field.Type.Bits() < algorithm.size {
+			return nil, nil, fmt.Errorf("%w: field '%s' must be an unsigned integer of at least %d bits to hold %s", ErrUnsupportedType, field.Name, algorithm.size, checksumTag.Algorithm)
+		}
+
+		start := -1
+
+		for j := 0; j < i; j++ {
+			if structType.Field(j).Name == checksumTag.StartField {
+				start = j
+			}
+		}
+
+		if start < 0 {
+			return nil, nil, fmt.Errorf("field '%s' checksum start field '%s' is not an earlier field", field.Name, checksumTag.StartField)
+		}
+
+		if spans == nil {
+			spans = map[int]*checksumSpan{}
+			starts = map[int][]*checksumSpan{}
+		}
+
+		span := &checksumSpan{tag: checksumTag, algorithm: algorithm}
+
+		spans[i] = span
+		starts[start] = append(starts[start], span)
+	}
+
+	return spans, starts, nil
+}
+
+func startChecksums(bb *bitbuffer.BitBuffer, spans []*checksumSpan) error {
+	for _, span := range spans {
+		capture, err := bb.Capture()
+		if err != nil {
+			return fmt.Errorf("checksum start field '%s': %w", span.tag.StartField, err)
+		}
+
+		span.capture = capture
+	}
+
+	return nil
+}
+
+func stopChecksums(spans map[int]*checksumSpan) {
+	for _, span := range spans {
+		if span.capture != nil {
+			_, _ = span.capture.Stop()
+		}
+	}
+}
+
+func (s *checksumSpan) result(name string) (uint64, error) {
+	data, err := s.capture.Stop()
+	if err != nil {
+		return 0, fmt.Errorf("field '%s' checksum: %w", name, err)
+	}
+
+	return s.algorithm.sum(data) & allOnes(s.algorithm.size), nil
+}
+
+func marshalChecksum(bb *bitbuffer.BitBuffer, name string, value reflect.Value, span *checksumSpan, tags reflect.StructTag, lenient bool) error {
+	sum, err := span.result(name)
+	if err != nil {
+		return err
+	}
+
+	endian, err := tagEndianness(tags, lenient)
+	if err != nil {
+		return err
+	}
+
+	if value.CanSet() {
+		value.SetUint(sum)
+	}
+
+	return bb.WriteUint(sum, endian, span.algorithm.size)
+}
+
+func unmarshalChecksum(bb *bitbuffer.BitBuffer, name string, value reflect.Value, span *checksumSpan, tags reflect.StructTag, lenient bool) error {
+	expected, err := span.result(name)
+	if err != nil {
+		return err
+	}
+
+	endian, err := tagEndianness(tags, lenient)
+	if err != nil {
+		return err
+	}
+
+	actual, err := bb.ReadUint(endian, span.algorithm.size)
+	if err != nil {
+		return err
+	}
+
+	value.SetUint(actual)
+
+	if actual != expected {
+		return &ChecksumError{Field: name, Algorithm: span.tag.Algorithm, Expected: expected, Actual: actual}
+	}
+
+	return nil
+}
+
+func crc8(data []byte) uint64 {
+	crc := byte(0)
+
+	for _, b := range data {
+		crc ^= b
+
+		for i := 0; i < 8; i++ {
+			if crc&0x80 != 0 {
+				crc = crc<<1 ^ 0x07
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return uint64(crc)
+}
+
+func crc16CCITT(data []byte) uint64 {
+	crc := uint16(0xffff)
+
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return uint64(crc)
+}
+
+func crc16Reflected(data []byte, initial uint16, polynomial uint16) uint64 {
+	crc := initial
+
+	for _, b := range data {
+		crc ^= uint16(b)
+
+		for i := 0; i < 8; i++ {
+			if crc&0x0001 != 0 {
+				crc = crc>>1 ^ polynomial
+			} else {
+				crc >>= 1
+			}
+		}
+	}
+
+	return uint64(crc)
+}
+
+func crc16Kermit(data []byte) uint64 {
+	return crc16Reflected(data, 0x0000, 0x8408)
+}
+
+func crc16Modbus(data []byte) uint64 {
+	return crc16Reflected(data, 0xffff, 0xa001)
+}
+
+func crc32IEEE(data []byte) uint64 {
+	return uint64(crc32.ChecksumIEEE(data))
+}
+
+func xor8(data []byte) uint64 {
+	sum := byte(0)
+
+	for _, b := range data {
+		sum ^= b
+	}
+
+	return uint64(sum)
+}
+
+func twosComplementSum8(data []byte) uint64 {
+	sum := byte(0)
+
+	for _, b := range data {
+		sum += b
+	}
+
+	return uint64(-sum)
+}
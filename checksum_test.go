@@ -0,0 +1,127 @@
+package bytecodec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecksumAlgorithms(t *testing.T) {
+	checkData := []byte("123456789")
+
+	testCases := map[string]uint64{
+		"crc8":        0xf4,
+		"crc16ccitt":  0x29b1,
+		"crc16kermit": 0x2189,
+		"crc16modbus": 0x4b37,
+		"crc32":       0xcbf43926,
+		"xor":         0x31,
+		"sum":         0x23,
+	}
+
+	for name, expectedValue := range testCases {
+		t.Run("verify check value of "+name, func(t *testing.T) {
+			algorithm, found := lookupChecksum(name)
+
+			assert.True(t, found)
+			assert.Equal(t, expectedValue, algorithm.sum(checkData))
+		})
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	type FrameUnderTest struct {
+		Start   uint8
+		Command uint8
+		Length  uint8
+		Data    []byte `bcsliceprefix:"8"`
+		FCS     uint8  `bcchecksum:"xor,Command"`
+	}
+
+	t.Run("verify checksum is computed and set on marshal", func(t *testing.T) {
+		instance := &FrameUnderTest{Start: 0xfe, Command: 0x21, Length: 0x01, Data: []byte{0x02, 0x03}}
+		actualBytes, err := Marshal(instance)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xfe, 0x21, 0x01, 0x02, 0x02, 0x03, 0x23}, actualBytes)
+		assert.Equal(t, uint8(0x23), instance.FCS)
+	})
+
+	t.Run("verify checksum is verified on unmarshal", func(t *testing.T) {
+		actualStruct := &FrameUnderTest{}
+		err := Unmarshal([]byte{0xfe, 0x21, 0x01, 0x02, 0x02, 0x03, 0x23}, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &FrameUnderTest{Start: 0xfe, Command: 0x21, Length: 0x01, Data: []byte{0x02, 0x03}, FCS: 0x23}, actualStruct)
+	})
+
+	t.Run("verify checksum mismatch returns a checksum error", func(t *testing.T) {
+		err := Unmarshal([]byte{0xfe, 0x21, 0x01, 0x02, 0x02, 0x03, 0x24}, &FrameUnderTest{})
+
+		assert.True(t, errors.Is(err, ErrChecksumMismatch))
+
+		checksumErr := &ChecksumError{}
+		assert.True(t, errors.As(err, &checksumErr))
+		assert.Equal(t, &ChecksumError{Field: "FCS", Algorithm: "xor", Expected: 0x23, Actual: 0x24}, checksumErr)
+	})
+
+	t.Run("verify multi byte checksums honour endianness", func(t *testing.T) {
+		type StructUnderTest struct {
+			Data [9]byte
+			CRC  uint16 `bcchecksum:"crc16ccitt,Data" bcendian:"big"`
+		}
+
+		instance := &StructUnderTest{}
+		copy(instance.Data[:], "123456789")
+
+		actualBytes, err := Marshal(instance)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{'1', '2', '3', '4', '5', '6', '7', '8', '9', 0x29, 0xb1}, actualBytes)
+	})
+
+	t.Run("verify registered checksums can be used", func(t *testing.T) {
+		err := RegisterChecksum("length", 16, func(data []byte) uint64 { return uint64(len(data)) })
+		assert.NoError(t, err)
+
+		type StructUnderTest struct {
+			One   uint8
+			Two   uint8
+			Check uint16 `bcchecksum:"length,One"`
+		}
+
+		actualBytes, err := Marshal(&StructUnderTest{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x00, 0x00, 0x02, 0x00}, actualBytes)
+	})
+
+	t.Run("verify invalid checksum declarations error", func(t *testing.T) {
+		type UnknownAlgorithm struct {
+			One   uint8
+			Check uint8 `bcchecksum:"md5,One"`
+		}
+
+		_, err := Marshal(&UnknownAlgorithm{})
+		assert.Error(t, err)
+
+		type LaterStart struct {
+			Check uint8 `bcchecksum:"xor,One"`
+			One   uint8
+		}
+
+		_, err = Marshal(&LaterStart{})
+		assert.Error(t, err)
+
+		type TooSmall struct {
+			One   uint8
+			Check uint8 `bcchecksum:"crc16ccitt,One"`
+		}
+
+		_, err = Marshal(&TooSmall{})
+		assert.True(t, errors.Is(err, ErrUnsupportedType))
+
+		assert.Error(t, RegisterChecksum("odd", 12, xor8))
+	})
+}
@@ -0,0 +1,92 @@
+package bytecodec
+
+import (
+	"reflect"
+
+	"github.com/shimmeringbee/bytecodec/bitbuffer"
+)
+
+// Decode unmarshals data into a new value of type T.
+func Decode[T any](data []byte, opts ...Option) (T, error) {
+	var v T
+	err := Unmarshal(data, &v, opts...)
+	return v, err
+}
+
+// DecodeInto unmarshals data into an existing value of type T.
+func DecodeInto[T any](data []byte, v *T, opts ...Option) error {
+	return Unmarshal(data, v, opts...)
+}
+
+// Encode marshals a value of type T.
+func Encode[T any](v T, opts ...Option) ([]byte, error) {
+	return Marshal(&v, opts...)
+}
+
+// Codec marshals and unmarshals values of type T with a fixed set of options. The type is validated when the Codec is
+// created, so a Codec held in a package variable reports tag errors and unsupported types at initialisation, rather
+// than when a value exercising them is first encoded. Creating the Codec also builds the plan of every struct within T,
+// its fields with their tags merged with the struct defaults, bitmap layouts, and which fields hold checksums and
+// lengths, which Marshal and Unmarshal otherwise build the first time they see each struct. Values are still walked by
+// reflection, following the plan. A Codec is safe for concurrent use.
+type Codec[T any] struct {
+	options *options
+}
+
+// NewCodec creates a Codec for type T, returning the *ValidationError from Validate if T can not be marshalled.
+func NewCodec[T any](opts ...Option) (*Codec[T], error) {
+	o := &options{}
+	applyOptions(o, opts)
+
+	t := reflect.TypeOf((*T)(nil)).Elem()
+
+	if err := Validate(t, opts...); err != nil {
+		return nil, err
+	}
+
+	buildPlans(t, "", o.lenientTags, map[planKey]bool{})
+
+	return &Codec[T]{options: o}, nil
+}
+
+// MustNewCodec creates a Codec for type T, panicking if T can not be marshalled, for use in package variables.
+func MustNewCodec[T any](opts ...Option) *Codec[T] {
+	c, err := NewCodec[T](opts...)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+// Decode unmarshals data into a new value of type T.
+func (c *Codec[T]) Decode(data []byte) (T, error) {
+	var v T
+	err := unmarshalBytes(data, &v, c.options)
+	return v, err
+}
+
+// DecodeInto unmarshals data into an existing value of type T.
+func (c *Codec[T]) DecodeInto(data []byte, v *T) error {
+	return unmarshalBytes(data, v, c.options)
+}
+
+// DecodeFromBitBuffer unmarshals from the BitBuffer into an existing value of type T.
+func (c *Codec[T]) DecodeFromBitBuffer(bb *bitbuffer.BitBuffer, v *T) error {
+	return unmarshalRoot(bb, v, c.options)
+}
+
+// Encode marshals a value of type T.
+func (c *Codec[T]) Encode(v *T) ([]byte, error) {
+	return marshalAppend(nil, v, c.options)
+}
+
+// Append marshals a value of type T and appends the result to dst, as MarshalAppend.
+func (c *Codec[T]) Append(dst []byte, v *T) ([]byte, error) {
+	return marshalAppend(dst, v, c.options)
+}
+
+// EncodeToBitBuffer marshals a value of type T to the BitBuffer.
+func (c *Codec[T]) EncodeToBitBuffer(bb *bitbuffer.BitBuffer, v *T) error {
+	return marshalRoot(bb, v, c.options)
+}
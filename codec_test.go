@@ -0,0 +1,177 @@
+package bytecodec
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/shimmeringbee/bytecodec/bitbuffer"
+	"github.com/stretchr/testify/assert"
+)
+
+type codecFrame struct {
+	Command uint8
+	Length  uint16 `bcendian:"big"`
+	Payload []byte `bcsliceprefix:"8"`
+}
+
+func TestDecodeEncode(t *testing.T) {
+	t.Run("verify Decode returns a new value", func(t *testing.T) {
+		frame, err := Decode[codecFrame]([]byte{0x01, 0x00, 0x02, 0x01, 0xaa})
+
+		assert.NoError(t, err)
+		assert.Equal(t, codecFrame{Command: 0x01, Length: 0x02, Payload: []byte{0xaa}}, frame)
+	})
+
+	t.Run("verify Decode of a pointer type allocates the value", func(t *testing.T) {
+		frame, err := Decode[*codecFrame]([]byte{0x01, 0x00, 0x02, 0x00})
+
+		assert.NoError(t, err)
+		assert.Equal(t, &codecFrame{Command: 0x01, Length: 0x02, Payload: []byte{}}, frame)
+	})
+
+	t.Run("verify DecodeInto populates an existing value", func(t *testing.T) {
+		frame := codecFrame{}
+		err := DecodeInto([]byte{0x01, 0x00, 0x02, 0x00}, &frame)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint16(0x02), frame.Length)
+	})
+
+	t.Run("verify Encode marshals a value", func(t *testing.T) {
+		data, err := Encode(codecFrame{Command: 0x01, Length: 0x02, Payload: []byte{0xaa}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x01, 0x00, 0x02, 0x01, 0xaa}, data)
+	})
+
+	t.Run("verify Encode uses pointer receiver marshalers of fields", func(t *testing.T) {
+		type StructUnderTest struct {
+			Custom CustomField
+		}
+
+		data, err := Encode(StructUnderTest{Custom: CustomField{Value: 1}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x03, 'O', 'N', 'E'}, data)
+	})
+}
+
+func TestCodec(t *testing.T) {
+	t.Run("verify a codec encodes and decodes", func(t *testing.T) {
+		codec, err := NewCodec[codecFrame]()
+		assert.NoError(t, err)
+
+		data, err := codec.Encode(&codecFrame{Command: 0x01, Length: 0x02, Payload: []byte{0xaa}})
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x01, 0x00, 0x02, 0x01, 0xaa}, data)
+
+		data, err = codec.Append([]byte{0xff}, &codecFrame{})
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xff, 0x00, 0x00, 0x00, 0x00}, data)
+
+		frame, err := codec.Decode([]byte{0x01, 0x00, 0x02, 0x01, 0xaa})
+		assert.NoError(t, err)
+		assert.Equal(t, codecFrame{Command: 0x01, Length: 0x02, Payload: []byte{0xaa}}, frame)
+	})
+
+	t.Run("verify a codec works with bit buffers", func(t *testing.T) {
+		codec := MustNewCodec[codecFrame]()
+		bb := bitbuffer.NewBitBuffer()
+
+		err := codec.EncodeToBitBuffer(bb, &codecFrame{Command: 0x01})
+		assert.NoError(t, err)
+
+		frame := codecFrame{}
+		err = codec.DecodeFromBitBuffer(bb, &frame)
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(0x01), frame.Command)
+	})
+
+	t.Run("verify a codec applies its options", func(t *testing.T) {
+		type OptionalStruct struct {
+			HasValue bool
+			Value    uint8 `bcincludeif:"HasValue"`
+		}
+
+		codec := MustNewCodec[OptionalStruct](Overwrite())
+
+		value := OptionalStruct{Value: 0x01}
+		err := codec.DecodeInto([]byte{0x00}, &value)
+
+		assert.NoError(t, err)
+		assert.Equal(t, OptionalStruct{}, value)
+	})
+
+	t.Run("verify creating a codec checks nested types and tags", func(t *testing.T) {
+		type Inner struct {
+			Name string `bcstringtype:"prefix,abc"`
+		}
+
+		type Outer struct {
+			Inner []Inner `bcsliceprefix:"8"`
+		}
+
+		_, err := NewCodec[Outer]()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Outer.Inner[].Name")
+	})
+
+	t.Run("verify creating a codec rejects unsupported types", func(t *testing.T) {
+		type Unsupported struct {
+			Values map[string]uint8
+		}
+
+		_, err := NewCodec[Unsupported]()
+		assert.True(t, errors.Is(err, ErrUnsupportedType))
+
+		assert.Panics(t, func() {
+			MustNewCodec[Unsupported]()
+		})
+	})
+
+	t.Run("verify creating a codec accepts recursive and custom types", func(t *testing.T) {
+		type Node struct {
+			Value    uint8
+			HasChild bool
+			Child    *Node `bcincludeif:"HasChild"`
+			Custom   CustomField
+		}
+
+		_, err := NewCodec[Node]()
+		assert.NoError(t, err)
+	})
+
+	t.Run("verify creating a codec builds the plan of each struct with its inherited defaults", func(t *testing.T) {
+		type Inner struct {
+			Name string
+		}
+
+		type Outer struct {
+			_       Defaults `bcendian:"big"`
+			Length  uint16
+			Inners  []*Inner `bcsliceprefix:"8"`
+			Ignored uint8    `bcignore:"true"`
+		}
+
+		codec := MustNewCodec[Outer]()
+
+		planCache.RLock()
+		outer := planCache.plans[planKey{structType: reflect.TypeOf(Outer{})}]
+		inner := planCache.plans[planKey{structType: reflect.TypeOf(Inner{}), defaults: `bcendian:"big"`}]
+		planCache.RUnlock()
+
+		assert.NotNil(t, outer)
+		assert.NotNil(t, inner)
+
+		assert.True(t, outer.fields[0].skip)
+		assert.Equal(t, reflect.StructTag(`bcendian:"big"`), outer.fields[1].tags)
+		assert.True(t, outer.fields[3].skip)
+		assert.Equal(t, reflect.StructTag(`bcendian:"big"`), inner.fields[0].tags)
+
+		data, err := codec.Encode(&Outer{Length: 0x0102, Inners: []*Inner{{Name: "a"}}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x01, 0x02, 0x01, 0x01, 'a'}, data)
+	})
+}
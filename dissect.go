@@ -0,0 +1,234 @@
+package bytecodec
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/shimmeringbee/bytecodec/bitbuffer"
+)
+
+// Record describes a value read while unmarshalling, with the Records of the fields or elements it contains in the
+// order they were read. Offset and Length are in bits from the start of the data, and Raw holds those bits packed from
+// the most significant bit with the final byte padded with zeros. Fields excluded by their bcincludeif condition are
+// recorded as Skipped with the Reason, and do not read any bits.
+type Record struct {
+	Path   string
+	Type   reflect.Type
+	Offset int
+	Length int
+	Raw    []byte
+	Value  interface{}
+
+	Skipped bool
+	Reason  string
+	Err     error
+
+	Children []*Record
+}
+
+// String formats the Record and its children one per line, indented by depth, for logs and annotating hexdumps. Values
+// of registered enums are formatted by their name.
+func (r *Record) String() string {
+	sb := &strings.Builder{}
+	r.format(sb, 0)
+	return sb.String()
+}
+
+func (r *Record) format(sb *strings.Builder, depth int) {
+	fmt.Fprintf(sb, "%s%d+%d %s %v", strings.Repeat("  ", depth), r.Offset, r.Length, r.Path, r.Type)
+
+	switch {
+	case r.Skipped:
+		fmt.Fprintf(sb, " skipped: %s", r.Reason)
+	case r.Err != nil:
+		fmt.Fprintf(sb, " [% x] error: %v", r.Raw, r.Err)
+	case len(r.Children) == 0:
+		fmt.Fprintf(sb, " [% x] = %s", r.Raw, EnumString(r.Value))
+	}
+
+	sb.WriteString("\n")
+
+	for _, child := range r.Children {
+		child.format(sb, depth+1)
+	}
+}
+
+// Dissect unmarshals data into v as Unmarshal, returning a Record of each value read. If unmarshalling fails the
+// Records read so far are returned with the error, the Records of the value which failed and those holding it having
+// Err set.
+func Dissect(data []byte, v interface{}, opts ...Option) (*Record, error) {
+	return DissectFromBitBuffer(bitbuffer.NewBitBufferFromBytes(data), v, opts...)
+}
+
+// DissectFromBitBuffer unmarshals from the BitBuffer into v as UnmarshalFromBitBuffer, returning a Record of each value
+// read as Dissect. Offsets are from the start of the buffer, rather than where unmarshalling started.
+func DissectFromBitBuffer(bb *bitbuffer.BitBuffer, v interface{}, opts ...Option) (*Record, error) {
+	o := newOptions(opts)
+	defer releaseOptions(o)
+
+	t := &tracer{fn: o.trace}
+	err := unmarshalTraced(bb, v, o, t, 0)
+
+	return t.root, err
+}
+
+// Trace calls fn with the Record of each value read while unmarshalling, as soon as the value has been read. The
+// Records of fields and elements are passed before the Record of the struct, array or slice holding them. Trace has no
+// effect on marshalling, so a Codec with Trace only traces when decoding.
+func Trace(fn func(*Record)) Option {
+	return func(o *options) {
+		o.trace = fn
+	}
+}
+
+// tracer builds the Records of an unmarshal, the methods do nothing on a nil tracer so that they can be called
+// unconditionally.
+type tracer struct {
+	fn   func(*Record)
+	root *Record
+	open []*Record
+}
+
+func (t *tracer) value(bb *bitbuffer.BitBuffer, ctx Context, value reflect.Value) {
+	if t == nil {
+		return
+	}
+
+	path := value.Type().Name()
+	if path == "" {
+		path = value.Type().String()
+	}
+
+	t.begin(bb, ctx, path, value)
+}
+
+func (t *tracer) field(bb *bitbuffer.BitBuffer, ctx Context, name string, value reflect.Value) {
+	if t == nil {
+		return
+	}
+
+	t.begin(bb, ctx, t.path()+"."+name, value)
+}
+
+func (t *tracer) element(bb *bitbuffer.BitBuffer, ctx Context, index int, value reflect.Value) {
+	if t == nil {
+		return
+	}
+
+	t.begin(bb, ctx, t.path()+"["+strconv.Itoa(index)+"]", value)
+}
+
+// bitmap records each field of a bitmap struct read from start, a bool field by the single bit it was read from and a
+// reserved field by the whole word.
+func (t *tracer) bitmap(bb *bitbuffer.BitBuffer, ctx Context, start int, layout bitmapLayout, structValue reflect.Value) {
+	if t == nil {
+		return
+	}
+
+	for _, bit := range layout.bits {
+		t.bits(bb, ctx, structValue.Type().Field(bit.index).Name, structValue.Field(bit.index), start+layout.bitOffset(bit.position), 1)
+	}
+
+	if layout.reserved >= 0 {
+		t.bits(bb, ctx, structValue.Type().Field(layout.reserved).Name, structValue.Field(layout.reserved), start, int(layout.tag.Size))
+	}
+}
+
+func (t *tracer) bits(bb *bitbuffer.BitBuffer, ctx Context, name string, value reflect.Value, offset int, length int) {
+	t.begin(bb, ctx, t.path()+"."+name, value)
+
+	r := t.open[len(t.open)-1]
+	t.open = t.open[:len(t.open)-1]
+
+	r.Offset = ctx.bitOffset + offset
+	r.Length = length
+	r.Raw, _ = bb.BitsBetween(offset, offset+length)
+	r.Value = value.Interface()
+
+	if t.fn != nil {
+		t.fn(r)
+	}
+}
+
+func (t *tracer) path() string {
+	if len(t.open) == 0 {
+		return ""
+	}
+
+	return t.open[len(t.open)-1].Path
+}
+
+func (t *tracer) begin(bb *bitbuffer.BitBuffer, ctx Context, path string, value reflect.Value) {
+	r := &Record{Path: path, Type: value.Type(), Offset: ctx.bitOffset + bb.Position()}
+
+	if len(t.open) == 0 {
+		t.root = r
+	} else {
+		parent := t.open[len(t.open)-1]
+		parent.Children = append(parent.Children, r)
+	}
+
+	t.open = append(t.open, r)
+}
+
+// skip marks the value being read as excluded by its bcincludeif condition, giving the value it was compared with.
+func (t *tracer) skip(ctx Context, tags reflect.StructTag, root reflect.Value, parent reflect.Value) {
+	if t == nil || len(t.open) == 0 {
+		return
+	}
+
+	r := t.open[len(t.open)-1]
+	r.Skipped = true
+	r.Reason = fmt.Sprintf("%s '%s' is not met", TagIncludeIf, tags.Get(TagIncludeIf))
+
+	includeIf, err := tagIncludeIf(tags)
+	if err != nil {
+		return
+	}
+
+	if flag, err := findIncludeIfValue(includeIf, root, parent, ctx.ancestors); err == nil && flag.CanInterface() {
+		r.Reason += fmt.Sprintf(", %s is %s", strings.Join(includeIf.FieldPath, "."), EnumString(flag.Interface()))
+	}
+}
+
+func (t *tracer) end(bb *bitbuffer.BitBuffer, ctx Context, value reflect.Value, err error) {
+	if t == nil || len(t.open) == 0 {
+		return
+	}
+
+	r := t.open[len(t.open)-1]
+	t.open = t.open[:len(t.open)-1]
+
+	start, end := r.Offset-ctx.bitOffset, bb.Position()
+
+	r.Length = end - start
+	r.Raw, _ = bb.BitsBetween(start, end)
+	r.Err = err
+
+	if err == nil && !r.Skipped && value.CanInterface() {
+		r.Value = value.Interface()
+	}
+
+	if t.fn != nil {
+		t.fn(r)
+	}
+}
+
+// discard removes the value being read, for elements of a slice which were not present.
+func (t *tracer) discard() {
+	if t == nil || len(t.open) == 0 {
+		return
+	}
+
+	t.open = t.open[:len(t.open)-1]
+
+	if len(t.open) == 0 {
+		t.root = nil
+		return
+	}
+
+	parent := t.open[len(t.open)-1]
+	parent.Children = parent.Children[:len(parent.Children)-1]
+}
@@ -0,0 +1,208 @@
+package bytecodec
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type dissectHeader struct {
+	Flags   uint8 `bcfieldwidth:"4"`
+	Version uint8 `bcfieldwidth:"4"`
+}
+
+type dissectFrame struct {
+	Header  dissectHeader
+	HasName bool
+	Name    string   `bcincludeif:"HasName" bcstringtype:"prefix,8"`
+	Values  []uint16 `bcsliceprefix:"8" bcendian:"big"`
+}
+
+func TestDissect(t *testing.T) {
+	data := []byte{0x12, 0x00, 0x02, 0x01, 0x02, 0x03, 0x04}
+
+	t.Run("verify each value read is recorded with its position, bits and value", func(t *testing.T) {
+		frame := &dissectFrame{}
+		root, err := Dissect(data, frame)
+
+		assert.NoError(t, err)
+		assert.Equal(t, dissectFrame{Header: dissectHeader{Flags: 1, Version: 2}, Values: []uint16{0x0102, 0x0304}}, *frame)
+
+		assert.Equal(t, "dissectFrame", root.Path)
+		assert.Equal(t, reflect.TypeOf(dissectFrame{}), root.Type)
+		assert.Equal(t, 0, root.Offset)
+		assert.Equal(t, 56, root.Length)
+		assert.Equal(t, data, root.Raw)
+		assert.Len(t, root.Children, 4)
+
+		header := root.Children[0]
+		assert.Equal(t, "dissectFrame.Header", header.Path)
+		assert.Equal(t, 8, header.Length)
+		assert.Len(t, header.Children, 2)
+
+		version := header.Children[1]
+		assert.Equal(t, "dissectFrame.Header.Version", version.Path)
+		assert.Equal(t, reflect.TypeOf(uint8(0)), version.Type)
+		assert.Equal(t, 4, version.Offset)
+		assert.Equal(t, 4, version.Length)
+		assert.Equal(t, []byte{0x20}, version.Raw)
+		assert.Equal(t, uint8(2), version.Value)
+
+		name := root.Children[2]
+		assert.Equal(t, "dissectFrame.Name", name.Path)
+		assert.True(t, name.Skipped)
+		assert.Equal(t, "bcincludeif 'HasName' is not met, HasName is false", name.Reason)
+		assert.Equal(t, 16, name.Offset)
+		assert.Equal(t, 0, name.Length)
+		assert.Nil(t, name.Value)
+
+		values := root.Children[3]
+		assert.Len(t, values.Children, 2)
+
+		second := values.Children[1]
+		assert.Equal(t, "dissectFrame.Values[1]", second.Path)
+		assert.Equal(t, 40, second.Offset)
+		assert.Equal(t, 16, second.Length)
+		assert.Equal(t, []byte{0x03, 0x04}, second.Raw)
+		assert.Equal(t, uint16(0x0304), second.Value)
+	})
+
+	t.Run("verify records are streamed to trace as each value is read", func(t *testing.T) {
+		var paths []string
+
+		err := Unmarshal(data, &dissectFrame{}, Trace(func(r *Record) {
+			paths = append(paths, r.Path)
+		}))
+
+		expectedPaths := []string{
+			"dissectFrame.Header.Flags",
+			"dissectFrame.Header.Version",
+			"dissectFrame.Header",
+			"dissectFrame.HasName",
+			"dissectFrame.Name",
+			"dissectFrame.Values[0]",
+			"dissectFrame.Values[1]",
+			"dissectFrame.Values",
+			"dissectFrame",
+		}
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedPaths, paths)
+	})
+
+	t.Run("verify the records read before a failure are returned with the error", func(t *testing.T) {
+		root, err := Dissect(data[:6], &dissectFrame{})
+
+		assert.True(t, errors.Is(err, io.EOF))
+		assert.True(t, errors.Is(root.Err, io.EOF))
+
+		values := root.Children[3]
+		assert.True(t, errors.Is(values.Err, io.EOF))
+		assert.Len(t, values.Children, 2)
+		assert.Equal(t, uint16(0x0102), values.Children[0].Value)
+		assert.NoError(t, values.Children[0].Err)
+		assert.True(t, errors.Is(values.Children[1].Err, io.EOF))
+		assert.Equal(t, []byte{0x03}, values.Children[1].Raw)
+	})
+
+	t.Run("verify offsets within a length are from the start of the data", func(t *testing.T) {
+		type StructUnderTest struct {
+			Length uint8 `bclength:"Inner"`
+			Inner  dissectHeader
+		}
+
+		root, err := Dissect([]byte{0x01, 0x12}, &StructUnderTest{})
+
+		assert.NoError(t, err)
+
+		version := root.Children[1].Children[1]
+		assert.Equal(t, 12, version.Offset)
+		assert.Equal(t, []byte{0x20}, version.Raw)
+	})
+
+	t.Run("verify elements of an unbounded slice which are not present are not recorded", func(t *testing.T) {
+		type StructUnderTest struct {
+			Values []uint16
+		}
+
+		root, err := Dissect([]byte{0x01, 0x00}, &StructUnderTest{})
+
+		assert.NoError(t, err)
+		assert.Len(t, root.Children[0].Children, 1)
+		assert.Equal(t, 16, root.Children[0].Length)
+	})
+
+	t.Run("verify the fields of a bitmap are recorded by their bits", func(t *testing.T) {
+		type Flags struct {
+			_        Bitmap `bcbitmap:"16"`
+			Low      bool   `bcbit:"0"`
+			High     bool   `bcbit:"9"`
+			Reserved uint16 `bcbit:"reserved"`
+		}
+
+		type StructUnderTest struct {
+			Flags Flags
+		}
+
+		var paths []string
+
+		root, err := Dissect([]byte{0x01, 0x82}, &StructUnderTest{}, Trace(func(r *Record) {
+			paths = append(paths, r.Path)
+		}))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"StructUnderTest.Flags.Low", "StructUnderTest.Flags.High", "StructUnderTest.Flags.Reserved", "StructUnderTest.Flags", "StructUnderTest"}, paths)
+
+		bits := root.Children[0].Children
+		assert.Len(t, bits, 3)
+
+		assert.Equal(t, 7, bits[0].Offset)
+		assert.Equal(t, 1, bits[0].Length)
+		assert.Equal(t, []byte{0x80}, bits[0].Raw)
+		assert.Equal(t, true, bits[0].Value)
+
+		assert.Equal(t, 14, bits[1].Offset)
+		assert.Equal(t, []byte{0x80}, bits[1].Raw)
+		assert.Equal(t, true, bits[1].Value)
+
+		assert.Equal(t, 0, bits[2].Offset)
+		assert.Equal(t, 16, bits[2].Length)
+		assert.Equal(t, uint16(0x8000), bits[2].Value)
+	})
+
+	t.Run("verify records are formatted one per line", func(t *testing.T) {
+		root, err := Dissect(data, &dissectFrame{})
+
+		expected := "0+56 dissectFrame bytecodec.dissectFrame\n" +
+			"  0+8 dissectFrame.Header bytecodec.dissectHeader\n" +
+			"    0+4 dissectFrame.Header.Flags uint8 [10] = 1\n" +
+			"    4+4 dissectFrame.Header.Version uint8 [20] = 2\n" +
+			"  8+8 dissectFrame.HasName bool [00] = false\n" +
+			"  16+0 dissectFrame.Name string skipped: bcincludeif 'HasName' is not met, HasName is false\n" +
+			"  16+40 dissectFrame.Values []uint16\n" +
+			"    24+16 dissectFrame.Values[0] uint16 [01 02] = 258\n" +
+			"    40+16 dissectFrame.Values[1] uint16 [03 04] = 772\n"
+
+		assert.NoError(t, err)
+		assert.Equal(t, expected, root.String())
+	})
+
+	t.Run("verify enum values are formatted and compared by their registered name", func(t *testing.T) {
+		registerEnumUnderTest(t)
+
+		type StructUnderTest struct {
+			Status enumUnderTest
+			Detail uint8 `bcincludeif:"Status==Fail"`
+		}
+
+		root, err := Dissect([]byte{0x01}, &StructUnderTest{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, enumBusy, root.Children[0].Value)
+		assert.Equal(t, "bcincludeif 'Status==Fail' is not met, Status is Busy", root.Children[1].Reason)
+		assert.Contains(t, root.String(), "StructUnderTest.Status bytecodec.enumUnderTest [01] = Busy\n")
+	})
+}
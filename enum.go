@@ -0,0 +1,139 @@
+package bytecodec
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var ErrUnknownEnumValue = errors.New("unknown enum value")
+
+type enum struct {
+	names  map[uint64]string
+	values map[string]uint64
+	order  []uint64
+}
+
+var enums = struct {
+	sync.RWMutex
+	types map[reflect.Type]enum
+}{types: map[reflect.Type]enum{}}
+
+// RegisterEnum registers the valid values of a named unsigned integer type, and their names. The argument must be a
+// map from the enum type to its name, for example map[Status]string{StatusOK: "OK"}. Registering a type again replaces
+// its previous values.
+func RegisterEnum(names interface{}) error {
+	mapValue := reflect.ValueOf(names)
+
+	if mapValue.Kind() != reflect.Map || mapValue.Type().Elem().Kind() != reflect.String {
+		return fmt.Errorf("%w: enum must be registered with a map of values to names", ErrUnsupportedType)
+	}
+
+	enumType := mapValue.Type().Key()
+
+	switch enumType.Kind() {
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+	default:
+		return fmt.Errorf("%w: enum '%v' must be an unsigned integer", ErrUnsupportedType, enumType)
+	}
+
+	e := enum{
+		names:  make(map[uint64]string, mapValue.Len()),
+		values: make(map[string]uint64, mapValue.Len()),
+	}
+
+	iter := mapValue.MapRange()
+
+	for iter.Next() {
+		value := iter.Key().Uint()
+		name := iter.Value().String()
+
+		if _, found := e.values[name]; found {
+			return fmt.Errorf("enum '%v' has duplicate name '%s'", enumType, name)
+		}
+
+		e.names[value] = name
+		e.values[name] = value
+		e.order = append(e.order, value)
+	}
+
+	sort.Slice(e.order, func(i, j int) bool { return e.order[i] < e.order[j] })
+
+	enums.Lock()
+	defer enums.Unlock()
+
+	enums.types[enumType] = e
+
+	return nil
+}
+
+// EnumName returns the registered name of an enum value, false is returned if the type is not a registered enum or the
+// value is unknown.
+func EnumName(v interface{}) (string, bool) {
+	value := reflect.ValueOf(v)
+
+	if !value.IsValid() {
+		return "", false
+	}
+
+	e, found := lookupEnum(value.Type())
+	if !found {
+		return "", false
+	}
+
+	name, found := e.names[value.Uint()]
+	return name, found
+}
+
+// EnumString returns the registered name of an enum value, or its number if it does not have one.
+func EnumString(v interface{}) string {
+	if name, found := EnumName(v); found {
+		return name
+	}
+
+	return fmt.Sprintf("%v", v)
+}
+
+func lookupEnum(t reflect.Type) (enum, bool) {
+	enums.RLock()
+	defer enums.RUnlock()
+
+	e, found := enums.types[t]
+	return e, found
+}
+
+func (e enum) validNames() string {
+	names := make([]string, 0, len(e.order))
+
+	for _, value := range e.order {
+		names = append(names, e.names[value])
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func checkEnum(name string, value reflect.Value) error {
+	e, found := lookupEnum(value.Type())
+	if !found {
+		return nil
+	}
+
+	if _, known := e.names[value.Uint()]; known {
+		return nil
+	}
+
+	return fmt.Errorf("%w: field '%s' of enum '%v' has value %d, expected one of %s", ErrUnknownEnumValue, name, value.Type(), value.Uint(), e.validNames())
+}
+
+func enumValue(t reflect.Type, name string) (uint64, bool) {
+	e, found := lookupEnum(t)
+	if !found {
+		return 0, false
+	}
+
+	value, found := e.values[name]
+	return value, found
+}
@@ -0,0 +1,148 @@
+package bytecodec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type enumUnderTest uint8
+
+const (
+	enumOK   enumUnderTest = 0x00
+	enumBusy enumUnderTest = 0x01
+	enumFail enumUnderTest = 0x80
+)
+
+func registerEnumUnderTest(t *testing.T) {
+	err := RegisterEnum(map[enumUnderTest]string{
+		enumOK:   "OK",
+		enumBusy: "Busy",
+		enumFail: "Fail",
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestRegisterEnum(t *testing.T) {
+	t.Run("verify that non maps can not be registered", func(t *testing.T) {
+		err := RegisterEnum([]string{"OK"})
+		assert.True(t, errors.Is(err, ErrUnsupportedType))
+	})
+
+	t.Run("verify that non unsigned integer types can not be registered", func(t *testing.T) {
+		err := RegisterEnum(map[string]string{"OK": "OK"})
+		assert.True(t, errors.Is(err, ErrUnsupportedType))
+	})
+
+	t.Run("verify that duplicate names are rejected", func(t *testing.T) {
+		type duplicateEnum uint16
+
+		err := RegisterEnum(map[duplicateEnum]string{0: "OK", 1: "OK"})
+		assert.Error(t, err)
+	})
+}
+
+func TestEnumName(t *testing.T) {
+	registerEnumUnderTest(t)
+
+	t.Run("verify that registered values are named", func(t *testing.T) {
+		name, found := EnumName(enumFail)
+
+		assert.True(t, found)
+		assert.Equal(t, "Fail", name)
+		assert.Equal(t, "Busy", EnumString(enumBusy))
+	})
+
+	t.Run("verify that unknown values and types are not named", func(t *testing.T) {
+		_, found := EnumName(enumUnderTest(0x02))
+		assert.False(t, found)
+
+		_, found = EnumName(uint8(0x00))
+		assert.False(t, found)
+
+		_, found = EnumName(nil)
+		assert.False(t, found)
+
+		assert.Equal(t, "2", EnumString(enumUnderTest(0x02)))
+	})
+}
+
+func TestEnumUnmarshal(t *testing.T) {
+	registerEnumUnderTest(t)
+
+	type StructUnderTest struct {
+		Status enumUnderTest
+	}
+
+	t.Run("verify unknown values are accepted by default", func(t *testing.T) {
+		actualStruct := &StructUnderTest{}
+		err := Unmarshal([]byte{0x02}, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, enumUnderTest(0x02), actualStruct.Status)
+	})
+
+	t.Run("verify known values are accepted with strict enums", func(t *testing.T) {
+		actualStruct := &StructUnderTest{}
+		err := Unmarshal([]byte{0x80}, actualStruct, StrictEnums())
+
+		assert.NoError(t, err)
+		assert.Equal(t, enumFail, actualStruct.Status)
+	})
+
+	t.Run("verify unknown values are rejected with strict enums, naming valid values", func(t *testing.T) {
+		actualStruct := &StructUnderTest{}
+		err := Unmarshal([]byte{0x02}, actualStruct, StrictEnums())
+
+		assert.True(t, errors.Is(err, ErrUnknownEnumValue))
+		assert.Contains(t, err.Error(), "field 'Status'")
+		assert.Contains(t, err.Error(), "expected one of OK, Busy, Fail")
+	})
+
+	t.Run("verify unknown values in slices are rejected with strict enums", func(t *testing.T) {
+		actualSlice := []enumUnderTest{}
+		err := Unmarshal([]byte{0x00, 0x01, 0x03}, &actualSlice, StrictEnums())
+
+		assert.True(t, errors.Is(err, ErrUnknownEnumValue))
+	})
+}
+
+func TestEnumIncludeIf(t *testing.T) {
+	registerEnumUnderTest(t)
+
+	type StructUnderTest struct {
+		Status enumUnderTest
+		Reason uint8 `bcincludeif:"Status==Fail"`
+		Next   uint8 `bcincludeif:"Status!=Busy"`
+	}
+
+	t.Run("verify enum names can be used in includeIf conditions", func(t *testing.T) {
+		actualBytes, err := Marshal(&StructUnderTest{Status: enumFail, Reason: 0x10, Next: 0x20})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x80, 0x10, 0x20}, actualBytes)
+
+		actualBytes, err = Marshal(&StructUnderTest{Status: enumBusy, Reason: 0x10, Next: 0x20})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x01}, actualBytes)
+	})
+
+	t.Run("verify presence can be derived from enum names", func(t *testing.T) {
+		type PresenceUnderTest struct {
+			Status enumUnderTest
+			Reason *uint8 `bcincludeif:"Status==Fail"`
+		}
+
+		reason := uint8(0x10)
+		instance := &PresenceUnderTest{Reason: &reason}
+
+		actualBytes, err := Marshal(instance, ImplicitPresence())
+
+		assert.NoError(t, err)
+		assert.Equal(t, enumFail, instance.Status)
+		assert.Equal(t, []byte{0x80, 0x10}, actualBytes)
+	})
+}
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
+	"sync"
 )
 
-func shouldIgnore(tags reflect.StructTag, root reflect.Value, parent reflect.Value) (bool, error) {
+func shouldIgnore(ctx Context, tags reflect.StructTag, root reflect.Value, parent reflect.Value) (bool, error) {
 	includeIf, err := tagIncludeIf(tags)
 
 	if err != nil {
@@ -14,94 +16,225 @@ func shouldIgnore(tags reflect.StructTag, root reflect.Value, parent reflect.Val
 	}
 
 	if includeIf.HasIncludeIf() {
-		includeBase := root
+		value, err := findIncludeIfValue(includeIf, root, parent, ctx.ancestors)
 
-		if includeIf.Relative {
-			includeBase = parent
+		if err != nil {
+			return false, err
 		}
 
-		i, err := findValue(includeBase, includeIf.FieldPath)
+		include, err := evaluateIncludeIf(value, includeIf)
+		return !include, err
+	}
 
-		if err != nil {
-			return false, err
+	return false, nil
+}
+
+// findIncludeIfValue finds the value a bcincludeif condition refers to. The ancestors are the structs enclosing the
+// field, outermost first, ending with its parent.
+func findIncludeIfValue(includeIf IncludeIfTag, root reflect.Value, parent reflect.Value, ancestors []reflect.Value) (reflect.Value, error) {
+	includeBase := root
+
+	if includeIf.Relative {
+		includeBase = parent
+	}
+
+	if includeIf.Ancestor > 0 {
+		level := len(ancestors) - 1 - includeIf.Ancestor
+
+		if level < 0 {
+			return reflect.Value{}, fmt.Errorf("includeIf path could not be parsed: %s is %d levels above a field %d levels deep", strings.Join(includeIf.FieldPath, "."), includeIf.Ancestor, len(ancestors)-1)
 		}
 
-		switch v := i.(type) {
-		case bool:
-			stringValue := includeIf.Value
+		includeBase = ancestors[level]
+	}
 
-			if stringValue == "" {
-				stringValue = "true"
-			}
+	return findValue(includeBase, includeIf.FieldPath)
+}
+
+var ancestorsPool = sync.Pool{
+	New: func() interface{} {
+		ancestors := make([]reflect.Value, 0, 8)
+		return &ancestors
+	},
+}
 
-			tagVal, err := strconv.ParseBool(stringValue)
+// acquireAncestors returns an empty slice to record the structs enclosing the current field, so that it does not
+// need to grow for each marshal or unmarshal.
+func acquireAncestors() *[]reflect.Value {
+	return ancestorsPool.Get().(*[]reflect.Value)
+}
 
-			switch includeIf.Operation {
-			case Equal:
-				return tagVal != v, err
-			case NotEqual:
-				return tagVal == v, err
-			default:
-				return false, fmt.Errorf("includeIf path could not be parsed: unable to compare end parameter (unknown comparison for bool)")
-			}
-		case uint8:
-			return compareUint(uint64(v), includeIf)
-		case uint16:
-			return compareUint(uint64(v), includeIf)
-		case uint32:
-			return compareUint(uint64(v), includeIf)
-		case uint64:
-			return compareUint(v, includeIf)
+func releaseAncestors(ancestors *[]reflect.Value) {
+	used := (*ancestors)[:cap(*ancestors)]
+
+	for i := range used {
+		used[i] = reflect.Value{}
+	}
+
+	*ancestors = used[:0]
+	ancestorsPool.Put(ancestors)
+}
+
+func evaluateIncludeIf(value reflect.Value, includeIf IncludeIfTag) (bool, error) {
+	switch value.Kind() {
+	case reflect.Bool:
+		tagVal, err := includeIf.boolValue()
+
+		switch includeIf.Operation {
+		case Equal:
+			return tagVal == value.Bool(), err
+		case NotEqual:
+			return tagVal != value.Bool(), err
 		default:
-			return false, fmt.Errorf("includeIf path could not be parsed: unable to compare end parameter (unknown type)")
+			return false, fmt.Errorf("includeIf path could not be parsed: unable to compare end parameter (unknown comparison for bool)")
 		}
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return compareUint(value, includeIf)
+	default:
+		return false, fmt.Errorf("includeIf path could not be parsed: unable to compare end parameter (unknown type)")
 	}
+}
 
-	return false, nil
+func (i IncludeIfTag) boolValue() (bool, error) {
+	stringValue := i.Value
+
+	if stringValue == "" {
+		stringValue = "true"
+	}
+
+	return strconv.ParseBool(stringValue)
 }
 
-func compareUint(v uint64, includeIf IncludeIfTag) (bool, error) {
-	stringValue := includeIf.Value
+func (i IncludeIfTag) uintValue(valueType reflect.Type) (uint64, error) {
+	stringValue := i.Value
 
 	if stringValue == "" {
 		stringValue = "0"
 	}
 
-	tagVal, err := strconv.ParseUint(stringValue, 10, 64)
+	if enumVal, found := enumValue(valueType, stringValue); found {
+		return enumVal, nil
+	}
+
+	return strconv.ParseUint(stringValue, 10, 64)
+}
+
+func compareUint(value reflect.Value, includeIf IncludeIfTag) (bool, error) {
+	tagVal, err := includeIf.uintValue(value.Type())
 
 	switch includeIf.Operation {
 	case Equal:
-		return tagVal != v, err
+		return tagVal == value.Uint(), err
 	case NotEqual:
-		return tagVal == v, err
+		return tagVal != value.Uint(), err
 	default:
-		return false, fmt.Errorf("includeIf path could not be parsed: unable to compare end parameter (unknown comparison for bool)")
+		return false, fmt.Errorf("includeIf path could not be parsed: unable to compare end parameter (unknown comparison for uint)")
 	}
 }
 
-func findValue(structValue reflect.Value, path []string) (interface{}, error) {
-	structType := structValue.Type()
+func findValue(structValue reflect.Value, path []string) (reflect.Value, error) {
+	value := structValue
+	previous := ""
 
-	thisLevel := path[0]
-	remainingPath := path[1:]
+	for _, part := range path {
+		name, indexes := splitPathIndexes(part)
 
-	for i := 0; i < structValue.NumField(); i++ {
-		value := structValue.Field(i)
-		field := structType.Field(i)
-		name := field.Name
+		if value.Kind() != reflect.Struct {
+			return reflect.Value{}, fmt.Errorf("includeIf path could not be parsed: %s is not a struct", previous)
+		}
+
+		field, found := findField(value, name)
+
+		if !found {
+			return reflect.Value{}, fmt.Errorf("includeIf path could not be parsed: %s not found", name)
+		}
+
+		var err error
+
+		if value, err = followPathIndexes(field, part, indexes); err != nil {
+			return reflect.Value{}, err
+		}
 
-		if name == thisLevel {
-			if len(remainingPath) >= 1 {
-				if value.Kind() != reflect.Struct {
-					return false, fmt.Errorf("includeIf path could not be parsed: %s is not a struct", name)
-				}
+		previous = part
+	}
 
-				return findValue(value, remainingPath)
+	return value, nil
+}
+
+// followPathIndexes indexes into the arrays and slices of a path part, dereferencing pointers along the way.
+func followPathIndexes(value reflect.Value, part string, indexes string) (reflect.Value, error) {
+	for {
+		for value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				return reflect.Value{}, fmt.Errorf("includeIf path could not be parsed: %s is nil", part)
 			}
 
-			return value.Interface(), nil
+			value = value.Elem()
+		}
+
+		if indexes == "" {
+			return value, nil
+		}
+
+		index, remaining, err := nextPathIndex(indexes)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("includeIf path could not be parsed: %s: %w", part, err)
+		}
+
+		if value.Kind() != reflect.Array && value.Kind() != reflect.Slice {
+			return reflect.Value{}, fmt.Errorf("includeIf path could not be parsed: %s is not an array or slice", part)
+		}
+
+		if index >= value.Len() {
+			return reflect.Value{}, fmt.Errorf("includeIf path could not be parsed: %s is out of range, length %d", part, value.Len())
+		}
+
+		value = value.Index(index)
+		indexes = remaining
+	}
+}
+
+// splitPathIndexes splits a path part such as "Items[2]" into the field name and its indexes.
+func splitPathIndexes(part string) (string, string) {
+	if i := strings.IndexByte(part, '['); i >= 0 {
+		return part[:i], part[i:]
+	}
+
+	return part, ""
+}
+
+// nextPathIndex parses the first index of indexes, returning it and the indexes which remain.
+func nextPathIndex(indexes string) (int, string, error) {
+	end := strings.IndexByte(indexes, ']')
+
+	if len(indexes) < 3 || indexes[0] != '[' || end < 0 {
+		return 0, "", fmt.Errorf("'%s' is not a valid index", indexes)
+	}
+
+	index, err := strconv.Atoi(indexes[1:end])
+	if err != nil {
+		return 0, "", err
+	}
+
+	return index, indexes[end+1:], nil
+}
+
+func findField(structValue reflect.Value, name string) (reflect.Value, bool) {
+	structType := structValue.Type()
+
+	for i := 0; i < structValue.NumField(); i++ {
+		if structType.Field(i).Name == name {
+			return structValue.Field(i), true
+		}
+	}
+
+	for i := 0; i < structValue.NumField(); i++ {
+		if isFlattened(structType.Field(i)) {
+			if value, found := findField(structValue.Field(i), name); found {
+				return value, true
+			}
 		}
 	}
 
-	return false, fmt.Errorf("includeIf path could not be parsed: %s not found", thisLevel)
+	return reflect.Value{}, false
 }
@@ -0,0 +1,106 @@
+package bytecodec
+
+import (
+	"math"
+	"reflect"
+	"sync"
+
+	"github.com/shimmeringbee/bytecodec/bitbuffer"
+)
+
+// StringInterner deduplicates strings read by Unmarshal, so that repeated values share a single allocation. It is
+// safe for concurrent use, and may be shared between calls to Unmarshal with the InternStrings option.
+type StringInterner struct {
+	mutex   sync.Mutex
+	strings map[string]string
+	limit   int
+}
+
+// NewStringInterner creates a StringInterner holding at most limit distinct strings, or any number if limit is zero.
+// Once full, strings which have not been seen are still returned but are not retained.
+func NewStringInterner(limit int) *StringInterner {
+	return &StringInterner{strings: map[string]string{}, limit: limit}
+}
+
+// Intern returns a string equal to data, reusing a previously returned string if there is one.
+func (i *StringInterner) Intern(data []byte) string {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if str, found := i.strings[string(data)]; found {
+		return str
+	}
+
+	return i.retain(string(data))
+}
+
+// Len returns the number of distinct strings held.
+func (i *StringInterner) Len() int {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	return len(i.strings)
+}
+
+func (i *StringInterner) internString(str string) string {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if existing, found := i.strings[str]; found {
+		return existing
+	}
+
+	return i.retain(str)
+}
+
+func (i *StringInterner) retain(str string) string {
+	if i.limit == 0 || len(i.strings) < i.limit {
+		i.strings[str] = str
+	}
+
+	return str
+}
+
+// unmarshalInternedString reads a string field through the interner, length prefixed strings are read without an
+// intermediate copy.
+func unmarshalInternedString(bb *bitbuffer.BitBuffer, interner *StringInterner, value reflect.Value, stringTag StringTypeTag) error {
+	if stringTag.Termination == Null {
+		str, err := bb.ReadStringNullTerminated(int(stringTag.Size))
+		if err != nil {
+			return err
+		}
+
+		value.SetString(interner.internString(str))
+		return nil
+	}
+
+	var length uint64
+	var err error
+
+	if stringTag.Varint {
+		length, err = bb.ReadUvarint()
+	} else {
+		length, err = bb.ReadUint(stringTag.Endian, int(stringTag.Size))
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if stringTag.Invalid && !stringTag.Varint && length == bitbuffer.InvalidLengthPrefix(int(stringTag.Size)) {
+		value.SetString("")
+		return nil
+	}
+
+	if length > math.MaxInt32 {
+		return bitbuffer.ErrorStringTooLarge
+	}
+
+	data, err := bb.ReadBytesShared(int(length))
+	if err != nil {
+		return err
+	}
+
+	value.SetString(interner.Intern(data))
+	return nil
+}
@@ -0,0 +1,211 @@
+package bytecodec
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/shimmeringbee/bytecodec/bitbuffer"
+)
+
+type lengthSpan struct {
+	tag         LengthTag
+	lengthIndex int
+	reservation *bitbuffer.Reservation
+	endian      bitbuffer.Endian
+	value       reflect.Value
+}
+
+// findLengths returns the lengths of a struct keyed by the index of the length field, and keyed by the index of the
+// field they describe.
+func findLengths(structType reflect.Type) (map[int]*lengthSpan, map[int]*lengthSpan, error) {
+	var lengths map[int]*lengthSpan
+	var targets map[int]*lengthSpan
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		lengthTag, err := tagLength(field.Tag)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !lengthTag.Present {
+			continue
+		}
+
+		if !isUint(field.Type.Kind()) {
+			return nil, nil, fmt.Errorf("%w: length field '%s' must be an unsigned integer", ErrUnsupportedType, field.Name)
+		}
+
+		target := -1
+
+		for j := i + 1; j < structType.NumField(); j++ {
+			if structType.Field(j).Name == lengthTag.Field {
+				target = j
+			}
+		}
+
+		if target < 0 {
+			return nil, nil, fmt.Errorf("length field '%s' describes '%s' which is not a later field", field.Name, lengthTag.Field)
+		}
+
+		if err := checkLengthTarget(field.Name, structType.Field(target), lengthTag); err != nil {
+			return nil, nil, err
+		}
+
+		if lengths == nil {
+			lengths = map[int]*lengthSpan{}
+			targets = map[int]*lengthSpan{}
+		}
+
+		span := &lengthSpan{tag: lengthTag, lengthIndex: i}
+
+		lengths[i] = span
+		targets[target] = span
+	}
+
+	return lengths, targets, nil
+}
+
+// checkLengthTarget rejects counts of fields which are not a slice, array or string, and tags on the field a length
+// describes which would encode a second length, as it would not be read back when the length is unmarshalled.
+func checkLengthTarget(name string, target reflect.StructField, lengthTag LengthTag) error {
+	if kind := target.Type.Kind(); lengthTag.Count && kind != reflect.Slice && kind != reflect.Array && kind != reflect.String {
+		return fmt.Errorf("%w: length field '%s' counts '%s' of type '%v' which has no count", ErrUnsupportedType, name, target.Name, target.Type)
+	}
+
+	if _, tagPresent := target.Tag.Lookup(TagStringType); tagPresent {
+		return fmt.Errorf("length field '%s' describes '%s' which can not also have a %s", name, target.Name, TagStringType)
+	}
+
+	if _, tagPresent := target.Tag.Lookup(TagSlicePrefix); tagPresent && lengthTag.Count {
+		return fmt.Errorf("length field '%s' counts '%s' which can not also have a %s", name, target.Name, TagSlicePrefix)
+	}
+
+	return nil
+}
+
+func reserveLength(bb *bitbuffer.BitBuffer, name string, value reflect.Value, span *lengthSpan, tags reflect.StructTag, lenient bool) error {
+	fieldWidth, err := tagFieldWidth(tags, lenient)
+	if err != nil {
+		return err
+	}
+
+	if fieldWidth.Varint {
+		return fmt.Errorf("%w: length field '%s' can not be a varint", ErrUnsupportedType, name)
+	}
+
+	endian, err := tagEndianness(tags, lenient)
+	if err != nil {
+		return err
+	}
+
+	reservation, err := bb.Reserve(fieldWidth.Width(value.Type().Bits()))
+	if err != nil {
+		return err
+	}
+
+	span.reservation = reservation
+	span.endian = endian
+	span.value = value
+
+	return nil
+}
+
+func marshalLengthTarget(bb *bitbuffer.BitBuffer, ctx Context, name string, value reflect.Value, root reflect.Value, parent reflect.Value, tags reflect.StructTag, span *lengthSpan) error {
+	var capture *bitbuffer.Capture
+
+	if !span.tag.Count {
+		c, err := bb.Capture()
+		if err != nil {
+			return fmt.Errorf("field '%s' length: %w", name, err)
+		}
+
+		defer func() { _, _ = c.Stop() }()
+		capture = c
+	}
+
+	if value.Kind() == reflect.String {
+		for _, b := range []byte(value.String()) {
+			if err := bb.WriteByte(b); err != nil {
+				return err
+			}
+		}
+	} else if err := marshalValue(bb, ctx, name, value, root, parent, tags); err != nil {
+		return err
+	}
+
+	var length uint64
+
+	if span.tag.Count {
+		length = uint64(value.Len())
+	} else {
+		data, err := capture.Stop()
+		if err != nil {
+			return fmt.Errorf("field '%s' length: %w", name, err)
+		}
+
+		length = uint64(len(data))
+	}
+
+	if span.reservation == nil {
+		return nil
+	}
+
+	if err := span.reservation.WriteUint(length, span.endian); err != nil {
+		return fmt.Errorf("field '%s' length %d: %w", name, length, err)
+	}
+
+	if span.value.CanSet() {
+		span.value.SetUint(length)
+	}
+
+	return nil
+}
+
+func unmarshalLengthTarget(bb *bitbuffer.BitBuffer, ctx Context, name string, value reflect.Value, root reflect.Value, parent reflect.Value, tags reflect.StructTag, length uint64, count bool) error {
+	if length > math.MaxInt32 {
+		return fmt.Errorf("field '%s' length %d is too large", name, length)
+	}
+
+	if value.Kind() == reflect.String {
+		data, err := bb.ReadBytesShared(int(length))
+		if err != nil {
+			return err
+		}
+
+		if ctx.options.interner != nil {
+			value.SetString(ctx.options.interner.Intern(data))
+		} else {
+			value.SetString(string(data))
+		}
+		return nil
+	}
+
+	if count {
+		if value.Kind() == reflect.Array {
+			return unmarshalArrayElements(bb, ctx, value, root, parent, tags, int(length))
+		}
+
+		return unmarshalSliceElements(bb, ctx, value, root, parent, tags, int(length))
+	}
+
+	data, err := bb.ReadBytesShared(int(length))
+	if err != nil {
+		return err
+	}
+
+	sub := bitbuffer.NewBitBufferFromBytes(data)
+	ctx.bitOffset += bb.Position() - len(data)*8
+
+	if err := unmarshalValue(sub, ctx, name, value, root, parent, tags); err != nil {
+		return err
+	}
+
+	if _, err := sub.ReadByte(); err == nil {
+		return fmt.Errorf("field '%s' did not consume all of its %d byte length", name, length)
+	}
+
+	return nil
+}
@@ -0,0 +1,220 @@
+package bytecodec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLength(t *testing.T) {
+	t.Run("verify byte length of a later field is filled on marshal", func(t *testing.T) {
+		type Payload struct {
+			One uint16
+			Two uint8
+		}
+
+		type StructUnderTest struct {
+			Length  uint16 `bclength:"Payload" bcendian:"big"`
+			Command uint8
+			Payload []Payload
+		}
+
+		instance := &StructUnderTest{Command: 0xaa, Payload: []Payload{{One: 0x0102, Two: 0x03}, {One: 0x0405, Two: 0x06}}}
+		actualBytes, err := Marshal(instance)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x00, 0x06, 0xaa, 0x02, 0x01, 0x03, 0x05, 0x04, 0x06}, actualBytes)
+		assert.Equal(t, uint16(6), instance.Length)
+
+		actualStruct := &StructUnderTest{}
+		err = Unmarshal(append(actualBytes, 0xff), actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, instance, actualStruct)
+	})
+
+	t.Run("verify element count of a later slice is filled on marshal", func(t *testing.T) {
+		type StructUnderTest struct {
+			Count  uint8 `bclength:"Values,count"`
+			Values []uint16
+			After  uint8
+		}
+
+		instance := &StructUnderTest{Values: []uint16{0x0001, 0x0002}, After: 0xff}
+		actualBytes, err := Marshal(instance)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x02, 0x01, 0x00, 0x02, 0x00, 0xff}, actualBytes)
+
+		actualStruct := &StructUnderTest{}
+		err = Unmarshal(actualBytes, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, instance, actualStruct)
+	})
+
+	t.Run("verify element counts of arrays and strings round trip", func(t *testing.T) {
+		type StructUnderTest struct {
+			ValuesCount uint8 `bclength:"Values,count"`
+			Values      [2]uint16
+			NameCount   uint8 `bclength:"Name,count"`
+			Name        string
+		}
+
+		instance := &StructUnderTest{Values: [2]uint16{0x0001, 0x0002}, Name: "Hi"}
+		actualBytes, err := Marshal(instance)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x02, 0x01, 0x00, 0x02, 0x00, 0x02, 0x48, 0x69}, actualBytes)
+
+		actualStruct := &StructUnderTest{}
+		err = Unmarshal(actualBytes, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, instance, actualStruct)
+
+		err = Unmarshal([]byte{0x03, 0x01, 0x00, 0x02, 0x00, 0x03, 0x00, 0x00}, actualStruct)
+		assert.Error(t, err)
+	})
+
+	t.Run("verify strings described by a length are written without prefix", func(t *testing.T) {
+		type StructUnderTest struct {
+			Length uint8 `bclength:"Name"`
+			Name   string
+			After  uint8
+		}
+
+		instance := &StructUnderTest{Name: "Hi", After: 0xff}
+		actualBytes, err := Marshal(instance)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x02, 0x48, 0x69, 0xff}, actualBytes)
+
+		actualStruct := &StructUnderTest{}
+		err = Unmarshal(actualBytes, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, instance, actualStruct)
+	})
+
+	t.Run("verify non byte aligned length fields are filled", func(t *testing.T) {
+		type StructUnderTest struct {
+			Flags  uint8 `bcfieldwidth:"4"`
+			Length uint8 `bclength:"Data" bcfieldwidth:"4"`
+			Data   []uint8
+		}
+
+		actualBytes, err := Marshal(&StructUnderTest{Flags: 0x0a, Data: []uint8{0x01, 0x02, 0x03}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xa3, 0x01, 0x02, 0x03}, actualBytes)
+	})
+
+	t.Run("verify lengths are included in checksums", func(t *testing.T) {
+		type StructUnderTest struct {
+			Length uint8 `bclength:"Data"`
+			Data   []uint8
+			FCS    uint8 `bcchecksum:"xor,Length"`
+		}
+
+		actualBytes, err := Marshal(&StructUnderTest{Data: []uint8{0x10, 0x20}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x02, 0x10, 0x20, 0x32}, actualBytes)
+	})
+
+	t.Run("verify lengths which do not fit error", func(t *testing.T) {
+		type StructUnderTest struct {
+			Length uint8 `bclength:"Data" bcfieldwidth:"2"`
+			Data   []uint8
+		}
+
+		_, err := Marshal(&StructUnderTest{Data: []uint8{0x01, 0x02, 0x03, 0x04}})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("verify fields which do not consume their length error on unmarshal", func(t *testing.T) {
+		type StructUnderTest struct {
+			Length uint8 `bclength:"Data"`
+			Data   uint16
+		}
+
+		err := Unmarshal([]byte{0x03, 0x01, 0x02, 0x03}, &StructUnderTest{})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("verify invalid length declarations error", func(t *testing.T) {
+		type EarlierField struct {
+			Data   []uint8
+			Length uint8 `bclength:"Data"`
+		}
+
+		_, err := Marshal(&EarlierField{})
+		assert.Error(t, err)
+
+		type NotUint struct {
+			Length string `bclength:"Data"`
+			Data   []uint8
+		}
+
+		_, err = Marshal(&NotUint{})
+		assert.True(t, errors.Is(err, ErrUnsupportedType))
+
+		type NoCount struct {
+			Length uint8 `bclength:"Data,count"`
+			Data   uint16
+		}
+
+		_, err = Marshal(&NoCount{})
+		assert.True(t, errors.Is(err, ErrUnsupportedType))
+	})
+
+	t.Run("verify counted fields with their own prefix error rather than desynchronise", func(t *testing.T) {
+		type StructUnderTest struct {
+			Count  uint8   `bclength:"Values,count"`
+			Values []uint8 `bcsliceprefix:"8"`
+			Tail   uint8
+		}
+
+		_, err := Marshal(&StructUnderTest{Values: []uint8{0x01, 0x02}, Tail: 0x09})
+		assert.Error(t, err)
+
+		err = Unmarshal([]byte{0x02, 0x02, 0x01, 0x02, 0x09}, &StructUnderTest{})
+		assert.Error(t, err)
+
+		assert.Error(t, Validate(StructUnderTest{}))
+
+		type StringType struct {
+			Length uint8  `bclength:"Name"`
+			Name   string `bcstringtype:"null"`
+		}
+
+		_, err = Marshal(&StringType{Name: "Hi"})
+		assert.Error(t, err)
+
+		assert.Error(t, Validate(StringType{}))
+	})
+
+	t.Run("verify byte lengths of slices with their own prefix round trip", func(t *testing.T) {
+		type StructUnderTest struct {
+			Length uint8   `bclength:"Values"`
+			Values []uint8 `bcsliceprefix:"8"`
+			Tail   uint8
+		}
+
+		instance := &StructUnderTest{Values: []uint8{0x01, 0x02}, Tail: 0x09}
+		actualBytes, err := Marshal(instance)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x03, 0x02, 0x01, 0x02, 0x09}, actualBytes)
+
+		actualStruct := &StructUnderTest{}
+		err = Unmarshal(actualBytes, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, instance, actualStruct)
+	})
+}
@@ -3,26 +3,79 @@ package bytecodec
 import (
 	"fmt"
 	"reflect"
+	"sync"
 
 	"github.com/shimmeringbee/bytecodec/bitbuffer"
 )
 
-func Marshal(v interface{}) ([]byte, error) {
+func Marshal(v interface{}, opts ...Option) ([]byte, error) {
 	bb := bitbuffer.NewBitBuffer()
 
-	if err := MarshalToBitBuffer(bb, v); err != nil {
+	if err := MarshalToBitBuffer(bb, v, opts...); err != nil {
 		return []byte{}, err
 	}
 
 	return bb.Bytes(), nil
 }
 
-func MarshalToBitBuffer(bb *bitbuffer.BitBuffer, v interface{}) error {
+var bitBufferPool = sync.Pool{
+	New: func() interface{} {
+		return bitbuffer.NewBitBuffer()
+	},
+}
+
+// MarshalAppend marshals v and appends the result to dst, returning the extended slice. Buffers are reused between
+// calls, so if dst has enough capacity no memory is allocated for the encoding.
+func MarshalAppend(dst []byte, v interface{}, opts ...Option) ([]byte, error) {
+	o := newOptions(opts)
+	defer releaseOptions(o)
+
+	return marshalAppend(dst, v, o)
+}
+
+func marshalAppend(dst []byte, v interface{}, o *options) ([]byte, error) {
+	bb := bitBufferPool.Get().(*bitbuffer.BitBuffer)
+	defer bitBufferPool.Put(bb)
+
+	bb.Reset()
+
+	if err := marshalRoot(bb, v, o); err != nil {
+		return dst, err
+	}
+
+	return append(dst, bb.Bytes()...), nil
+}
+
+func MarshalToBitBuffer(bb *bitbuffer.BitBuffer, v interface{}, opts ...Option) error {
+	o := newOptions(opts)
+	defer releaseOptions(o)
+
+	return marshalRoot(bb, v, o)
+}
+
+func marshalRoot(bb *bitbuffer.BitBuffer, v interface{}, o *options) error {
 	val := reflect.Indirect(reflect.ValueOf(v))
 
+	ancestors := acquireAncestors()
+	defer releaseAncestors(ancestors)
+
 	ctx := Context{
 		Root:         val,
 		CurrentIndex: 0,
+		options:      o,
+		ancestors:    *ancestors,
+	}
+
+	if ctx.options.implicitPresence {
+		if err := derivePresence(val); err != nil {
+			return err
+		}
+	}
+
+	if ctx.options.strictPresence {
+		if err := checkPresence(val); err != nil {
+			return err
+		}
 	}
 
 	return marshalValue(bb, ctx, "root", val, val, val, "")
@@ -31,37 +84,65 @@ func MarshalToBitBuffer(bb *bitbuffer.BitBuffer, v interface{}) error {
 func marshalValue(bb *bitbuffer.BitBuffer, ctx Context, name string, value reflect.Value, root reflect.Value, parent reflect.Value, tags reflect.StructTag) (err error) {
 	kind := value.Kind()
 
-	endian := tagEndianness(tags)
-
-	if skip, err := shouldIgnore(tags, root, parent); skip || err != nil {
+	if skip, err := shouldIgnore(ctx, tags, root, parent); skip || err != nil {
 		return err
 	}
 
-	fieldWidth, err := tagFieldWidth(tags)
+	fieldWidth, err := tagFieldWidth(tags, ctx.options.lenientTags)
 
 	if err != nil {
 		return
 	}
 
+	endian, err := tagEndianness(tags, ctx.options.lenientTags)
+
+	if err != nil {
+		return
+	}
+
+	// Values within a struct use the Marshaler of their pointer, the root value does not so that a Marshaler can
+	// delegate to MarshalToBitBuffer with itself.
+	if kind != reflect.Ptr && len(ctx.ancestors) > 0 && reflect.PtrTo(value.Type()).Implements(marshalerType) {
+		return marshalPtr(bb, ctx, name, addressable(value), root, parent, tags)
+	}
+
+	if _, tagPresent := tags.Lookup(TagScale); tagPresent {
+		return marshalScaled(bb, name, value, endian, tags)
+	}
+
+	if _, tagPresent := tags.Lookup(TagBCD); tagPresent {
+		return marshalBCD(bb, name, value, tags)
+	}
+
+	if isTimeType(value.Type()) {
+		return marshalTime(bb, name, value, endian, tags)
+	}
+
+	if fieldWidth.Varint && !varintKind(kind) {
+		return fmt.Errorf("%w: %s varint can not be used on field '%s' of type '%v'", ErrUnsupportedType, TagFieldWidth, name, kind)
+	}
+
 	switch kind {
 	case reflect.Bool:
 		err = marshalBool(bb, fieldWidth.Width(8), value.Bool())
 	case reflect.Uint8:
-		err = bb.WriteUint(value.Uint(), endian, fieldWidth.Width(8))
+		err = marshalUint(bb, endian, fieldWidth, 8, value.Uint())
 	case reflect.Uint16:
-		err = bb.WriteUint(value.Uint(), endian, fieldWidth.Width(16))
+		err = marshalUint(bb, endian, fieldWidth, 16, value.Uint())
 	case reflect.Uint32:
-		err = bb.WriteUint(value.Uint(), endian, fieldWidth.Width(32))
+		err = marshalUint(bb, endian, fieldWidth, 32, value.Uint())
 	case reflect.Uint64:
-		err = bb.WriteUint(value.Uint(), endian, fieldWidth.Width(64))
+		err = marshalUint(bb, endian, fieldWidth, 64, value.Uint())
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		err = marshalInt(bb, name, fieldWidth, value.Int())
 	case reflect.Struct:
-		err = marshalStruct(bb, value, root)
+		err = marshalStruct(bb, ctx, value, root)
 	case reflect.Array, reflect.Slice:
 		err = marshalArrayOrSlice(bb, ctx, value, root, parent, tags)
 	case reflect.String:
-		err = marshalString(bb, value, tags)
+		err = marshalString(bb, value, tags, ctx.options.lenientTags)
 	case reflect.Ptr:
-		err = marshalPtr(bb, ctx, value)
+		err = marshalPtr(bb, ctx, name, value, root, parent, tags)
 	default:
 		err = fmt.Errorf("%w: field '%s' of type '%v'", ErrUnsupportedType, name, kind)
 	}
@@ -69,10 +150,8 @@ func marshalValue(bb *bitbuffer.BitBuffer, ctx Context, name string, value refle
 	return
 }
 
-func marshalPtr(bb *bitbuffer.BitBuffer, ctx Context, value reflect.Value) error {
-	marshaler := reflect.TypeOf((*Marshaler)(nil)).Elem()
-
-	if value.Type().Implements(marshaler) {
+func marshalPtr(bb *bitbuffer.BitBuffer, ctx Context, name string, value reflect.Value, root reflect.Value, parent reflect.Value, tags reflect.StructTag) error {
+	if value.Type().Implements(marshalerType) {
 		retVals := value.MethodByName("Marshal").Call([]reflect.Value{reflect.ValueOf(bb), reflect.ValueOf(ctx)})
 
 		if retVals[0].IsNil() {
@@ -82,26 +161,123 @@ func marshalPtr(bb *bitbuffer.BitBuffer, ctx Context, value reflect.Value) error
 		return retVals[0].Interface().(error)
 	}
 
-	return fmt.Errorf("%w: field does not support the Marshaler interface", ErrUnsupportedType)
+	if stringTag, nullable := nullableString(value.Type(), tags, ctx.options.lenientTags); nullable {
+		var stringValue *string
+
+		if !value.IsNil() {
+			str := value.Elem().String()
+			stringValue = &str
+		}
+
+		return bb.WriteStringLengthPrefixedNullable(stringValue, stringTag.Endian, int(stringTag.Size))
+	}
+
+	if value.IsNil() {
+		return fmt.Errorf("%w: field '%s' is a nil pointer", ErrUnsupportedType, name)
+	}
+
+	return marshalValue(bb, ctx, name, value.Elem(), root, parent, tags)
+}
+
+func addressable(value reflect.Value) reflect.Value {
+	if value.CanAddr() {
+		return value.Addr()
+	}
+
+	ptr := reflect.New(value.Type())
+	ptr.Elem().Set(value)
+
+	return ptr
+}
+
+func marshalStruct(bb *bitbuffer.BitBuffer, ctx Context, structValue reflect.Value, root reflect.Value) error {
+	ctx.Root = structValue
+	ctx.CurrentIndex = 0
+	ctx.ancestors = append(ctx.ancestors, structValue)
+
+	plan := cachedStructPlan(structValue.Type(), ctx.defaults, ctx.options.lenientTags)
+	if plan.bitmapErr != nil {
+		return plan.bitmapErr
+	}
+
+	if plan.isBitmap {
+		return marshalBitmap(bb, plan.bitmap, structValue)
+	}
+
+	return marshalFields(bb, ctx, structValue, root, structValue)
 }
 
-func marshalStruct(bb *bitbuffer.BitBuffer, structValue reflect.Value, root reflect.Value) error {
+func marshalFields(bb *bitbuffer.BitBuffer, ctx Context, structValue reflect.Value, root reflect.Value, parent reflect.Value) (err error) {
 	structType := structValue.Type()
 
-	ctx := Context{
-		Root:         structValue,
-		CurrentIndex: 0,
+	plan := cachedStructPlan(structType, ctx.defaults, ctx.options.lenientTags)
+	if plan.fieldsErr != nil {
+		return plan.fieldsErr
 	}
 
-	for i := 0; i < structValue.NumField(); i++ {
+	ctx.defaults = plan.defaults
+
+	var spans map[int]*checksumSpan
+	var starts map[int][]*checksumSpan
+
+	if plan.checksums {
+		if spans, starts, err = findChecksums(structType); err != nil {
+			return err
+		}
+
+		defer stopChecksums(spans)
+	}
+
+	var lengths, targets map[int]*lengthSpan
+
+	if plan.lengths {
+		if lengths, targets, err = findLengths(structType); err != nil {
+			return err
+		}
+	}
+
+	for i, field := range plan.fields {
 		value := structValue.Field(i)
-		field := structType.Field(i)
-		tags := field.Tag
-		name := field.Name
+		tags := field.tags
+		name := field.name
 
 		ctx.CurrentIndex = i
 
-		if err := marshalValue(bb, ctx, name, value, root, structValue, tags); err != nil {
+		if err := startChecksums(bb, starts[i]); err != nil {
+			return err
+		}
+
+		if field.skip {
+			continue
+		}
+
+		checksum, length, target := spans[i], lengths[i], targets[i]
+		flattened := field.flattened
+
+		if flattened || checksum != nil || length != nil || target != nil {
+			if skip, err := shouldIgnore(ctx, tags, root, parent); skip || err != nil {
+				if err != nil {
+					return err
+				}
+
+				continue
+			}
+		}
+
+		switch {
+		case flattened:
+			err = marshalFields(bb, ctx, value, root, parent)
+		case checksum != nil:
+			err = marshalChecksum(bb, name, value, checksum, tags, ctx.options.lenientTags)
+		case length != nil:
+			err = reserveLength(bb, name, value, length, tags, ctx.options.lenientTags)
+		case target != nil:
+			err = marshalLengthTarget(bb, ctx, name, value, root, parent, tags, target)
+		default:
+			err = marshalValue(bb, ctx, name, value, root, parent, tags)
+		}
+
+		if err != nil {
 			return err
 		}
 	}
@@ -110,19 +286,43 @@ func marshalStruct(bb *bitbuffer.BitBuffer, structValue reflect.Value, root refl
 }
 
 func marshalArrayOrSlice(bb *bitbuffer.BitBuffer, ctx Context, value reflect.Value, root reflect.Value, parent reflect.Value, tags reflect.StructTag) error {
-	length, err := tagSlicePrefix(tags)
+	length, err := tagSlicePrefix(tags, ctx.options.lenientTags)
 	if err != nil {
 		return err
 	}
 
-	if length.HasPrefix() {
+	if length.Varint {
+		if err := bb.WriteUvarint(uint64(value.Len())); err != nil {
+			return err
+		}
+	} else if length.HasPrefix() {
+		if length.Invalid {
+			invalidLength := bitbuffer.InvalidLengthPrefix(int(length.Size))
+
+			if value.Kind() == reflect.Slice && value.IsNil() {
+				return bb.WriteUint(invalidLength, length.Endian, int(length.Size))
+			}
+
+			if uint64(value.Len()) >= invalidLength {
+				return fmt.Errorf("cannot marshal slice of length %d into %d bit prefix with invalid length", value.Len(), length.Size)
+			}
+		}
+
 		if err := bb.WriteUint(uint64(value.Len()), length.Endian, int(length.Size)); err != nil {
 			return err
 		}
 	}
 
+	if isBulkBytes(ctx, value.Type(), tags) && (value.Kind() == reflect.Slice || value.CanAddr()) {
+		if value.Kind() == reflect.Array {
+			value = value.Slice(0, value.Len())
+		}
+
+		return bb.WriteBytes(value.Bytes())
+	}
+
 	for i := 0; i < value.Len(); i++ {
-		name := fmt.Sprintf("array[%d]", i)
+		name := arrayElementNames.name(i)
 		if err := marshalValue(bb, ctx, name, value.Index(i), root, parent, tags); err != nil {
 			return err
 		}
@@ -131,8 +331,8 @@ func marshalArrayOrSlice(bb *bitbuffer.BitBuffer, ctx Context, value reflect.Val
 	return nil
 }
 
-func marshalString(bb *bitbuffer.BitBuffer, value reflect.Value, tags reflect.StructTag) error {
-	stringTag, err := tagStringType(tags)
+func marshalString(bb *bitbuffer.BitBuffer, value reflect.Value, tags reflect.StructTag, lenient bool) error {
+	stringTag, err := tagStringType(tags, lenient)
 	if err != nil {
 		return err
 	}
@@ -143,9 +343,33 @@ func marshalString(bb *bitbuffer.BitBuffer, value reflect.Value, tags reflect.St
 		return bb.WriteStringNullTerminated(stringValue, int(stringTag.Size))
 	}
 
+	if stringTag.Varint {
+		return bb.WriteStringVarintPrefixed(stringValue)
+	}
+
+	if stringTag.Invalid {
+		return bb.WriteStringLengthPrefixedNullable(&stringValue, stringTag.Endian, int(stringTag.Size))
+	}
+
 	return bb.WriteStringLengthPrefixed(stringValue, stringTag.Endian, int(stringTag.Size))
 }
 
+func marshalUint(bb *bitbuffer.BitBuffer, endian bitbuffer.Endian, fieldWidth FieldWidthTag, defaultWidth int, value uint64) error {
+	if fieldWidth.Varint {
+		return bb.WriteUvarint(value)
+	}
+
+	return bb.WriteUint(value, endian, fieldWidth.Width(defaultWidth))
+}
+
+func marshalInt(bb *bitbuffer.BitBuffer, name string, fieldWidth FieldWidthTag, value int64) error {
+	if !fieldWidth.Varint {
+		return fmt.Errorf("%w: signed field '%s' must be a varint", ErrUnsupportedType, name)
+	}
+
+	return bb.WriteVarint(value)
+}
+
 func marshalBool(bb *bitbuffer.BitBuffer, bitSize int, value bool) error {
 	byteValue := 0x00
 
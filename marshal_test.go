@@ -345,6 +345,49 @@ func TestMarshal(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("verify nil string pointer with invalid keyword marshals as invalid length", func(t *testing.T) {
+		type StructUnderTest struct {
+			One *string `bcstringtype:"prefix,8,invalid"`
+			Two *string `bcstringtype:"prefix,16,invalid"`
+		}
+
+		two := ""
+		instance := &StructUnderTest{Two: &two}
+		actualBytes, err := Marshal(instance)
+
+		expectedBytes := []byte{0xff, 0x00, 0x00}
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedBytes, actualBytes)
+	})
+
+	t.Run("verify string with invalid keyword errors if length is the invalid length", func(t *testing.T) {
+		type StructUnderTest struct {
+			One string `bcstringtype:"prefix,8,invalid"`
+		}
+
+		instance := &StructUnderTest{One: strings.Repeat("a", 255)}
+		_, err := Marshal(instance)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("verify nil slice with invalid keyword marshals as invalid length, and empty slice as zero", func(t *testing.T) {
+		type StructUnderTest struct {
+			One   []byte `bcsliceprefix:"8,invalid"`
+			Two   []byte `bcsliceprefix:"16,invalid"`
+			Three []byte `bcsliceprefix:"8,invalid"`
+		}
+
+		instance := &StructUnderTest{Three: []byte{}}
+		actualBytes, err := Marshal(instance)
+
+		expectedBytes := []byte{0xff, 0xff, 0xff, 0x00}
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedBytes, actualBytes)
+	})
+
 	t.Run("verify null terminated string marshals", func(t *testing.T) {
 		type StructUnderTest struct {
 			One string `bcstringtype:"null"`
@@ -514,6 +557,94 @@ func TestMarshal(t *testing.T) {
 		assert.Equal(t, expectedBytes, actualBytes)
 	})
 
+	t.Run("verify includeIf refers to the fields of an ancestor", func(t *testing.T) {
+		type Inner struct {
+			Two uint8 `bcincludeif:"...Header.Flags==1"`
+		}
+
+		type Middle struct {
+			Inner Inner
+		}
+
+		type StructUnderTest struct {
+			Header struct {
+				Flags uint8
+			}
+			Middle Middle
+		}
+
+		instance := &StructUnderTest{Middle: Middle{Inner: Inner{Two: 2}}}
+		instance.Header.Flags = 1
+		actualBytes, err := Marshal(instance)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x01, 0x02}, actualBytes)
+
+		instance.Header.Flags = 0
+		actualBytes, err = Marshal(instance)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x00}, actualBytes)
+	})
+
+	t.Run("verify includeIf in slice elements refers to the element and the struct holding the slice", func(t *testing.T) {
+		type Element struct {
+			HasValue bool
+			Value    uint8 `bcincludeif:"HasValue"`
+			Extra    uint8 `bcincludeif:"..Extended"`
+		}
+
+		type StructUnderTest struct {
+			Extended bool
+			Elements []Element
+		}
+
+		instance := &StructUnderTest{Extended: true, Elements: []Element{{HasValue: true, Value: 1, Extra: 2}, {Extra: 3}}}
+		actualBytes, err := Marshal(instance)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x01, 0x01, 0x01, 0x02, 0x00, 0x03}, actualBytes)
+	})
+
+	t.Run("verify includeIf indexes slices and dereferences pointers", func(t *testing.T) {
+		type Header struct {
+			Flags []bool `bcsliceprefix:"8"`
+		}
+
+		type StructUnderTest struct {
+			Header *Header
+			Two    uint8 `bcincludeif:"Header.Flags[1]"`
+		}
+
+		instance := &StructUnderTest{Header: &Header{Flags: []bool{false, true}}, Two: 2}
+		actualBytes, err := Marshal(instance)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x02, 0x00, 0x01, 0x02}, actualBytes)
+	})
+
+	t.Run("fails if an includeIf index is out of range", func(t *testing.T) {
+		type StructUnderTest struct {
+			Flags []bool `bcsliceprefix:"8"`
+			Two   uint8  `bcincludeif:"Flags[2]"`
+		}
+
+		_, err := Marshal(&StructUnderTest{Flags: []bool{true}})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("fails if an includeIf path goes above the root", func(t *testing.T) {
+		type StructUnderTest struct {
+			One bool
+			Two uint8 `bcincludeif:"..One"`
+		}
+
+		_, err := Marshal(&StructUnderTest{})
+
+		assert.Error(t, err)
+	})
+
 	t.Run("verify struct with includeIf marshals a field which matches the integer condition", func(t *testing.T) {
 		type StructUnderTest struct {
 			One uint32
@@ -626,6 +757,220 @@ func TestMarshal(t *testing.T) {
 		assert.Equal(t, expectedBytes, actualBytes)
 	})
 
+	t.Run("verify varints on non integer fields error", func(t *testing.T) {
+		type BoolUnderTest struct {
+			One bool `bcfieldwidth:"varint"`
+		}
+
+		type StringUnderTest struct {
+			One string `bcfieldwidth:"varint"`
+		}
+
+		_, err := Marshal(&BoolUnderTest{One: true})
+		assert.True(t, errors.Is(err, ErrUnsupportedType))
+
+		_, err = Marshal(&StringUnderTest{One: "a"})
+		assert.True(t, errors.Is(err, ErrUnsupportedType))
+	})
+
+	t.Run("verify signed integers which are not varints error", func(t *testing.T) {
+		type StructUnderTest struct {
+			One int16
+		}
+
+		_, err := Marshal(&StructUnderTest{One: -1})
+
+		assert.True(t, errors.Is(err, ErrUnsupportedType))
+	})
+
+	t.Run("verify varint integers, slice prefixes and string prefixes are marshalled", func(t *testing.T) {
+		type StructUnderTest struct {
+			Unsigned uint32  `bcfieldwidth:"varint"`
+			Signed   int16   `bcfieldwidth:"varint"`
+			Slice    []uint8 `bcsliceprefix:"varint"`
+			String   string  `bcstringtype:"prefix,varint"`
+		}
+
+		instance := &StructUnderTest{Unsigned: 300, Signed: -65, Slice: []uint8{0xaa}, String: "Hi"}
+		actualBytes, err := Marshal(instance)
+
+		expectedBytes := []byte{0xac, 0x02, 0x81, 0x01, 0x01, 0xaa, 0x02, 0x48, 0x69}
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedBytes, actualBytes)
+	})
+
+	t.Run("verify byte slices and arrays are marshalled in bulk, including when not aligned", func(t *testing.T) {
+		type NamedByte uint8
+
+		type StructUnderTest struct {
+			Flags  uint8 `bcfieldwidth:"4"`
+			Slice  []byte
+			Array  [2]NamedByte
+			Padded uint8 `bcfieldwidth:"4"`
+		}
+
+		instance := &StructUnderTest{Flags: 0x0a, Slice: []byte{0x12, 0x34}, Array: [2]NamedByte{0x56, 0x78}, Padded: 0x0b}
+		actualBytes, err := Marshal(instance)
+
+		expectedBytes := []byte{0xa1, 0x23, 0x45, 0x67, 0x8b}
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedBytes, actualBytes)
+	})
+
+	t.Run("verify pointers to values are marshalled", func(t *testing.T) {
+		type StructUnderTest struct {
+			One *uint16 `bcendian:"big"`
+		}
+
+		one := uint16(0x8001)
+		instance := &StructUnderTest{One: &one}
+		actualBytes, err := Marshal(instance)
+
+		expectedBytes := []byte{0x80, 0x01}
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedBytes, actualBytes)
+	})
+
+	t.Run("verify nil pointers which are not excluded error", func(t *testing.T) {
+		type StructUnderTest struct {
+			One *uint16
+		}
+
+		instance := &StructUnderTest{}
+		_, err := Marshal(instance)
+
+		assert.True(t, errors.Is(err, ErrUnsupportedType))
+	})
+
+	t.Run("verify nil pointers which are excluded by includeIf are not marshalled", func(t *testing.T) {
+		type StructUnderTest struct {
+			HasOne bool
+			One    *uint16 `bcincludeif:"HasOne"`
+		}
+
+		instance := &StructUnderTest{}
+		actualBytes, err := Marshal(instance)
+
+		expectedBytes := []byte{0x00}
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedBytes, actualBytes)
+	})
+
+	t.Run("verify embedded structs are flattened for relative includeIf references", func(t *testing.T) {
+		type Header struct {
+			HasTwo bool
+		}
+
+		type StructUnderTest struct {
+			Header
+			One uint8
+			Two uint8 `bcincludeif:"HasTwo"`
+		}
+
+		instance := &StructUnderTest{Header: Header{HasTwo: true}, One: 1, Two: 2}
+		actualBytes, err := Marshal(instance)
+
+		expectedBytes := []byte{0x01, 0x01, 0x02}
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedBytes, actualBytes)
+	})
+
+	t.Run("verify fields of embedded structs can reference fields of the embedding struct", func(t *testing.T) {
+		type Optional struct {
+			Two uint8 `bcincludeif:"HasTwo"`
+		}
+
+		type StructUnderTest struct {
+			HasTwo bool
+			Optional
+		}
+
+		instance := &StructUnderTest{HasTwo: false, Optional: Optional{Two: 2}}
+		actualBytes, err := Marshal(instance)
+
+		expectedBytes := []byte{0x00}
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedBytes, actualBytes)
+	})
+
+	t.Run("verify struct defaults apply to fields without tags", func(t *testing.T) {
+		type StructUnderTest struct {
+			_     Defaults `bcendian:"big" bcstringtype:"null"`
+			One   uint16
+			Two   uint16 `bcendian:"little"`
+			Three string
+		}
+
+		instance := &StructUnderTest{One: 0x0102, Two: 0x0102, Three: "a"}
+		actualBytes, err := Marshal(instance)
+
+		expectedBytes := []byte{0x01, 0x02, 0x02, 0x01, 'a', 0x00}
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedBytes, actualBytes)
+	})
+
+	t.Run("verify struct defaults are inherited by nested structs", func(t *testing.T) {
+		type Nested struct {
+			One uint16
+		}
+
+		type StructUnderTest struct {
+			_      Defaults `bcendian:"big"`
+			Nested Nested
+		}
+
+		instance := &StructUnderTest{Nested: Nested{One: 0x0102}}
+		actualBytes, err := Marshal(instance)
+
+		expectedBytes := []byte{0x01, 0x02}
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedBytes, actualBytes)
+	})
+
+	t.Run("verify fields with ignore tag and unexported fields are not marshalled", func(t *testing.T) {
+		type StructUnderTest struct {
+			One      uint8
+			Received chan bool `bcignore:"true"`
+			two      uint8
+			Three    uint8 `bcignore:"false"`
+		}
+
+		instance := &StructUnderTest{One: 1, two: 2, Three: 3}
+		actualBytes, err := Marshal(instance)
+
+		expectedBytes := []byte{0x01, 0x03}
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedBytes, actualBytes)
+	})
+
+	t.Run("verify exported fields of unexported embedded structs are marshalled", func(t *testing.T) {
+		type header struct {
+			One uint8
+		}
+
+		type StructUnderTest struct {
+			header
+			Two uint8
+		}
+
+		instance := &StructUnderTest{header: header{One: 1}, Two: 2}
+		actualBytes, err := Marshal(instance)
+
+		expectedBytes := []byte{0x01, 0x02}
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedBytes, actualBytes)
+	})
+
 	t.Run("supports pointers which implement Marshaller interface", func(t *testing.T) {
 		type StructUnderTest struct {
 			One *CustomField
@@ -654,6 +999,77 @@ func TestMarshal(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, expectedBytes, actualBytes)
 	})
+
+	t.Run("supports values whose pointer implements Marshaller interface", func(t *testing.T) {
+		type StructUnderTest struct {
+			One CustomField
+		}
+
+		actualBytes, err := Marshal(StructUnderTest{One: CustomField{Value: 1}})
+
+		expectedBytes := []byte{0x03, 'O', 'N', 'E'}
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedBytes, actualBytes)
+	})
+
+	t.Run("marshals the root value by its fields, so Marshallers can delegate with themselves", func(t *testing.T) {
+		actualBytes, err := Marshal(&DelegatingField{Value: 0x01})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x01}, actualBytes)
+
+		type StructUnderTest struct {
+			One DelegatingField
+		}
+
+		actualBytes, err = Marshal(&StructUnderTest{One: DelegatingField{Value: 0x01}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xaa, 0x01}, actualBytes)
+	})
+}
+
+func TestMarshalAppend(t *testing.T) {
+	type StructUnderTest struct {
+		Command uint8
+		Length  uint16 `bcendian:"big"`
+		Payload []byte `bcsliceprefix:"8"`
+		Name    string
+		Values  [2]uint16
+		Flag    bool  `bcfieldwidth:"1"`
+		Extra   uint8 `bcfieldwidth:"7" bcincludeif:"Flag"`
+	}
+
+	value := &StructUnderTest{Command: 0x01, Length: 0x0203, Payload: []byte{0x04}, Name: "a", Values: [2]uint16{0x05, 0x06}}
+
+	t.Run("verify marshalled bytes are appended to the destination", func(t *testing.T) {
+		actualBytes, err := MarshalAppend([]byte{0xff}, value)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xff, 0x01, 0x02, 0x03, 0x01, 0x04, 0x01, 'a', 0x05, 0x00, 0x06, 0x00, 0x00}, actualBytes)
+	})
+
+	t.Run("verify destination is returned unchanged on error", func(t *testing.T) {
+		actualBytes, err := MarshalAppend([]byte{0xff}, &struct{ Value complex64 }{})
+
+		assert.Error(t, err)
+		assert.Equal(t, []byte{0xff}, actualBytes)
+	})
+
+	t.Run("verify marshalling into a destination with capacity does not allocate", func(t *testing.T) {
+		if raceEnabled {
+			t.Skip("pooled values are dropped at random under the race detector")
+		}
+
+		dst := make([]byte, 0, 64)
+
+		allocs := testing.AllocsPerRun(100, func() {
+			_, _ = MarshalAppend(dst[:0], value)
+		})
+
+		assert.Equal(t, float64(0), allocs)
+	})
 }
 
 type CustomField struct {
@@ -687,6 +1103,27 @@ func (f *CustomField) Unmarshal(bb *bitbuffer.BitBuffer, ctx Context) error {
 	return nil
 }
 
+// DelegatingField marshals a marker byte followed by its fields, by marshalling itself.
+type DelegatingField struct {
+	Value uint8
+}
+
+func (f *DelegatingField) Marshal(bb *bitbuffer.BitBuffer, ctx Context) error {
+	if err := bb.WriteByte(0xaa); err != nil {
+		return err
+	}
+
+	return MarshalToBitBuffer(bb, f)
+}
+
+func (f *DelegatingField) Unmarshal(bb *bitbuffer.BitBuffer, ctx Context) error {
+	if _, err := bb.ReadByte(); err != nil {
+		return err
+	}
+
+	return UnmarshalFromBitBuffer(bb, f)
+}
+
 type CustomFieldTwo struct {
 	Value uint8
 }
@@ -2,6 +2,7 @@ package bytecodec
 
 import (
 	"errors"
+	"fmt"
 	"reflect"
 
 	"github.com/shimmeringbee/bytecodec/bitbuffer"
@@ -12,6 +13,46 @@ var ErrUnsupportedType = errors.New("unsupported type")
 type Context struct {
 	Root         reflect.Value
 	CurrentIndex int
+
+	options   *options
+	defaults  reflect.StructTag
+	ancestors []reflect.Value
+	trace     *tracer
+	bitOffset int
+}
+
+// MarshalToBitBuffer marshals v to the BitBuffer as MarshalToBitBuffer, with the options of the marshal the Context
+// was passed from, for a Marshaler which encodes its value as another type. The options are only valid until the
+// Marshaler returns.
+func (c Context) MarshalToBitBuffer(bb *bitbuffer.BitBuffer, v interface{}) error {
+	return marshalRoot(bb, v, c.opts())
+}
+
+// UnmarshalFromBitBuffer unmarshals from the BitBuffer into v as UnmarshalFromBitBuffer, with the options of the
+// unmarshal the Context was passed from, for an Unmarshaler which decodes its value as another type. When tracing, the
+// Records of the values read are added beneath the Record of the value being unmarshalled.
+func (c Context) UnmarshalFromBitBuffer(bb *bitbuffer.BitBuffer, v interface{}) error {
+	return unmarshalTraced(bb, v, c.opts(), c.trace, c.bitOffset)
+}
+
+// Intern returns a string equal to data, from the StringInterner of the InternStrings option if the unmarshal the
+// Context was passed from has one.
+func (c Context) Intern(data []byte) string {
+	if interner := c.opts().interner; interner != nil {
+		return interner.Intern(data)
+	}
+
+	return string(data)
+}
+
+// opts returns the options of the Context, or the defaults for a Context which was not passed from a marshal or
+// unmarshal.
+func (c Context) opts() *options {
+	if c.options == nil {
+		return defaultOptions
+	}
+
+	return c.options
 }
 
 type Marshaler interface {
@@ -21,3 +62,38 @@ type Marshaler interface {
 type Unmarshaler interface {
 	Unmarshal(*bitbuffer.BitBuffer, Context) error
 }
+
+var (
+	marshalerType   = reflect.TypeOf((*Marshaler)(nil)).Elem()
+	unmarshalerType = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+)
+
+const cachedElementNames = 256
+
+// elementNames names the elements of arrays and slices for errors, the names of the first elements are built once
+// rather than each time a collection is marshalled or unmarshalled.
+type elementNames struct {
+	prefix string
+	names  []string
+}
+
+func newElementNames(prefix string) elementNames {
+	e := elementNames{prefix: prefix, names: make([]string, cachedElementNames)}
+
+	for i := range e.names {
+		e.names[i] = fmt.Sprintf("%s[%d]", prefix, i)
+	}
+
+	return e
+}
+
+func (e elementNames) name(i int) string {
+	if i < len(e.names) {
+		return e.names[i]
+	}
+
+	return fmt.Sprintf("%s[%d]", e.prefix, i)
+}
+
+var arrayElementNames = newElementNames("array")
+var sliceElementNames = newElementNames("slice")
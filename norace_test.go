@@ -0,0 +1,5 @@
+//go:build !race
+
+package bytecodec
+
+const raceEnabled = false
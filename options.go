@@ -0,0 +1,116 @@
+package bytecodec
+
+import (
+	"sync"
+)
+
+type Option func(*options)
+
+type options struct {
+	implicitPresence bool
+	strictPresence   bool
+	strictEnums      bool
+	zeroCopy         bool
+	overwrite        bool
+	lenientTags      bool
+	interner         *StringInterner
+	trace            func(*Record)
+}
+
+var defaultOptions = &options{}
+
+var optionsPool = sync.Pool{
+	New: func() interface{} {
+		return &options{}
+	},
+}
+
+// newOptions applies opts to pooled options, which must be returned with releaseOptions once marshalling or
+// unmarshalling has finished.
+func newOptions(opts []Option) *options {
+	if len(opts) == 0 {
+		return defaultOptions
+	}
+
+	o := optionsPool.Get().(*options)
+	applyOptions(o, opts)
+
+	return o
+}
+
+func applyOptions(o *options, opts []Option) {
+	*o = options{}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+}
+
+func releaseOptions(o *options) {
+	if o != defaultOptions {
+		*o = options{}
+		optionsPool.Put(o)
+	}
+}
+
+// ImplicitPresence sets the fields referenced by bcincludeif conditions on optional pointer and slice fields, such as a
+// *string, from whether the optional field is nil, before marshalling. The value being marshalled must be a pointer.
+func ImplicitPresence() Option {
+	return func(o *options) {
+		o.implicitPresence = true
+	}
+}
+
+// StrictPresence causes marshalling to fail if an optional field is set but excluded by its bcincludeif condition, or
+// is nil but included by it.
+func StrictPresence() Option {
+	return func(o *options) {
+		o.strictPresence = true
+	}
+}
+
+// StrictEnums causes unmarshalling to fail if a field of a type registered with RegisterEnum contains a value which was
+// not registered.
+func StrictEnums() Option {
+	return func(o *options) {
+		o.strictEnums = true
+	}
+}
+
+// ZeroCopy causes unmarshalling to set byte aligned []byte fields to sub-slices of the input, rather than copies. The
+// fields share memory with the input, so the input must not be modified or reused while they are in use. Their capacity
+// is limited to their length, so appending to them copies rather than overwriting the input. Fields which are not byte
+// aligned, and arrays, are always copied. As slices are unmarshalled into their existing capacity, a value holding
+// zero-copy slices must not be unmarshalled into again without ZeroCopy, as it would overwrite the earlier input.
+func ZeroCopy() Option {
+	return func(o *options) {
+		o.zeroCopy = true
+	}
+}
+
+// Overwrite causes unmarshalling to zero fields which are excluded by their bcincludeif condition, rather than leaving
+// their previous value. Slices are always unmarshalled into their existing capacity, with Overwrite the elements being
+// reused are not zeroed first, so nested slices also keep their capacity. Together these allow a value to be reused for
+// each message without allocating, and without values from an earlier message remaining.
+func Overwrite() Option {
+	return func(o *options) {
+		o.overwrite = true
+	}
+}
+
+// LenientTags restores the tag parsing of earlier versions, for definitions written against them. When lenient, unknown
+// bcendian values are treated as little endian, unknown keywords in bcsliceprefix and bcstringtype are ignored, and out
+// of range widths are accepted. Otherwise these return an error when the tag is used.
+func LenientTags() Option {
+	return func(o *options) {
+		o.lenientTags = true
+	}
+}
+
+// InternStrings causes unmarshalling to return strings from the interner, so that repeated values share an allocation.
+// Strings with a length prefix which have been seen before are read without allocating.
+func InternStrings(interner *StringInterner) Option {
+	return func(o *options) {
+		o.interner = interner
+	}
+}
@@ -0,0 +1,124 @@
+package bytecodec
+
+import (
+	"reflect"
+	"sync"
+)
+
+// structPlan is the layout of a struct type resolved from its tags, with the defaults inherited from the structs
+// holding it. Errors found while resolving it are kept, and returned when the struct is marshalled or unmarshalled.
+type structPlan struct {
+	defaults  reflect.StructTag
+	bitmap    bitmapLayout
+	isBitmap  bool
+	bitmapErr error
+	fields    []fieldPlan
+	checksums bool
+	lengths   bool
+	fieldsErr error
+}
+
+// fieldPlan is a field of a struct, with its tags merged with the defaults of the struct.
+type fieldPlan struct {
+	name      string
+	tags      reflect.StructTag
+	skip      bool
+	flattened bool
+}
+
+type planKey struct {
+	structType reflect.Type
+	defaults   reflect.StructTag
+	lenient    bool
+}
+
+var planCache = struct {
+	sync.RWMutex
+	plans map[planKey]*structPlan
+}{plans: map[planKey]*structPlan{}}
+
+// cachedStructPlan returns the plan of a struct type, only building it the first time the type is seen with the
+// defaults, as the same types are marshalled and unmarshalled repeatedly.
+func cachedStructPlan(structType reflect.Type, defaults reflect.StructTag, lenient bool) *structPlan {
+	key := planKey{structType: structType, defaults: defaults, lenient: lenient}
+
+	planCache.RLock()
+	plan, found := planCache.plans[key]
+	planCache.RUnlock()
+
+	if found {
+		return plan
+	}
+
+	plan = newStructPlan(structType, defaults, lenient)
+
+	planCache.Lock()
+	planCache.plans[key] = plan
+	planCache.Unlock()
+
+	return plan
+}
+
+func newStructPlan(structType reflect.Type, inherited reflect.StructTag, lenient bool) *structPlan {
+	plan := &structPlan{defaults: structDefaults(structType, inherited)}
+
+	plan.bitmap, plan.isBitmap, plan.bitmapErr = findBitmapLayout(structType, inherited, lenient)
+
+	spans, _, err := findChecksums(structType)
+	if err != nil {
+		plan.fieldsErr = err
+	}
+
+	lengths, _, err := findLengths(structType)
+	if err != nil && plan.fieldsErr == nil {
+		plan.fieldsErr = err
+	}
+
+	plan.checksums = len(spans) > 0
+	plan.lengths = len(lengths) > 0
+	plan.fields = make([]fieldPlan, structType.NumField())
+
+	for i := range plan.fields {
+		field := structType.Field(i)
+
+		plan.fields[i] = fieldPlan{
+			name:      field.Name,
+			tags:      withDefaults(field.Tag, plan.defaults),
+			skip:      isDefaultsMarker(field) || isIgnored(field),
+			flattened: isFlattened(field),
+		}
+	}
+
+	return plan
+}
+
+// buildPlans builds the plans of every struct reachable from a type, with the defaults they inherit, following the same
+// path as marshalValue.
+func buildPlans(t reflect.Type, defaults reflect.StructTag, lenient bool, seen map[planKey]bool) {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Array, reflect.Slice:
+		if !hasMarshaler(t.Elem()) {
+			buildPlans(t.Elem(), defaults, lenient, seen)
+		}
+	case reflect.Struct:
+		key := planKey{structType: t, defaults: defaults, lenient: lenient}
+
+		if seen[key] || isTimeType(t) {
+			return
+		}
+
+		seen[key] = true
+		plan := cachedStructPlan(t, defaults, lenient)
+
+		for i, field := range plan.fields {
+			if fieldType := t.Field(i).Type; !field.skip && !hasMarshaler(fieldType) {
+				buildPlans(fieldType, plan.defaults, lenient, seen)
+			}
+		}
+	}
+}
+
+// hasMarshaler returns if a type within a struct is marshalled by its own Marshaler, or that of its pointer.
+func hasMarshaler(t reflect.Type) bool {
+	return t.Implements(marshalerType) || (t.Kind() != reflect.Ptr && reflect.PtrTo(t).Implements(marshalerType))
+}
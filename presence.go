@@ -0,0 +1,167 @@
+package bytecodec
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var ErrPresenceMismatch = errors.New("optional field presence does not match includeIf condition")
+
+type presenceVisitor func(name string, value reflect.Value, includeIf IncludeIfTag, root reflect.Value, ancestors []reflect.Value) error
+
+func walkPresence(value reflect.Value, root reflect.Value, ancestors []reflect.Value, visit presenceVisitor) error {
+	switch value.Kind() {
+	case reflect.Struct:
+		return walkPresenceFields(value, root, append(ancestors, value), visit)
+	case reflect.Array, reflect.Slice:
+		for i := 0; i < value.Len(); i++ {
+			if err := walkPresence(value.Index(i), root, ancestors, visit); err != nil {
+				return err
+			}
+		}
+	case reflect.Ptr:
+		if !value.IsNil() && value.Elem().Kind() == reflect.Struct {
+			return walkPresence(value.Elem(), root, ancestors, visit)
+		}
+	}
+
+	return nil
+}
+
+// walkPresenceFields visits the fields of a struct, the last of the ancestors is the struct which relative paths are
+// resolved from, which differs from the struct for embedded structs.
+func walkPresenceFields(structValue reflect.Value, root reflect.Value, ancestors []reflect.Value, visit presenceVisitor) error {
+	structType := structValue.Type()
+
+	for i := 0; i < structValue.NumField(); i++ {
+		fieldValue := structValue.Field(i)
+		field := structType.Field(i)
+
+		if isIgnored(field) {
+			continue
+		}
+
+		if isFlattened(field) {
+			if err := walkPresenceFields(fieldValue, root, ancestors, visit); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		includeIf, err := tagIncludeIf(field.Tag)
+		if err != nil {
+			return err
+		}
+
+		if includeIf.HasIncludeIf() && isNillable(fieldValue.Kind()) {
+			if err := visit(field.Name, fieldValue, includeIf, root, ancestors); err != nil {
+				return err
+			}
+		}
+
+		if err := walkPresence(fieldValue, root, ancestors, visit); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func isNillable(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Ptr, reflect.Slice:
+		return true
+	default:
+		return false
+	}
+}
+
+func derivePresence(root reflect.Value) error {
+	derived := map[uintptr]bool{}
+
+	return walkPresence(root, root, nil, func(name string, value reflect.Value, includeIf IncludeIfTag, root reflect.Value, ancestors []reflect.Value) error {
+		present := !value.IsNil()
+
+		flag, err := findIncludeIfValue(includeIf, root, ancestors[len(ancestors)-1], ancestors)
+		if err != nil {
+			return err
+		}
+
+		if !flag.CanSet() {
+			return fmt.Errorf("%w: field '%s' has an includeIf condition which can not be set, marshal a pointer", ErrPresenceMismatch, name)
+		}
+
+		flagAddress := flag.Addr().Pointer()
+
+		if previous, found := derived[flagAddress]; found && previous != present {
+			return fmt.Errorf("%w: field '%s' presence conflicts with another field sharing its includeIf condition", ErrPresenceMismatch, name)
+		}
+
+		derived[flagAddress] = present
+
+		return setIncludeIf(flag, includeIf, present, name)
+	})
+}
+
+func setIncludeIf(flag reflect.Value, includeIf IncludeIfTag, include bool, name string) error {
+	switch flag.Kind() {
+	case reflect.Bool:
+		tagVal, err := includeIf.boolValue()
+		if err != nil {
+			return err
+		}
+
+		if includeIf.Operation == NotEqual {
+			tagVal = !tagVal
+		}
+
+		flag.SetBool(tagVal == include)
+		return nil
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		tagVal, err := includeIf.uintValue(flag.Type())
+		if err != nil {
+			return err
+		}
+
+		if (includeIf.Operation == Equal) == include {
+			flag.SetUint(tagVal)
+			return nil
+		}
+
+		if flag.Uint() == tagVal {
+			return fmt.Errorf("%w: field '%s' presence can not be derived, includeIf condition has no single value", ErrPresenceMismatch, name)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("includeIf path could not be parsed: unable to compare end parameter (unknown type)")
+	}
+}
+
+func checkPresence(root reflect.Value) error {
+	return walkPresence(root, root, nil, func(name string, value reflect.Value, includeIf IncludeIfTag, root reflect.Value, ancestors []reflect.Value) error {
+		present := !value.IsNil()
+
+		flag, err := findIncludeIfValue(includeIf, root, ancestors[len(ancestors)-1], ancestors)
+		if err != nil {
+			return err
+		}
+
+		include, err := evaluateIncludeIf(flag, includeIf)
+		if err != nil {
+			return err
+		}
+
+		if present && !include {
+			return fmt.Errorf("%w: field '%s' is set but excluded", ErrPresenceMismatch, name)
+		}
+
+		if !present && include {
+			return fmt.Errorf("%w: field '%s' is nil but included", ErrPresenceMismatch, name)
+		}
+
+		return nil
+	})
+}
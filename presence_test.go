@@ -0,0 +1,202 @@
+package bytecodec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImplicitPresence(t *testing.T) {
+	t.Run("verify bool flag is set from a present pointer", func(t *testing.T) {
+		type StructUnderTest struct {
+			HasTwo bool
+			Two    *uint8 `bcincludeif:"HasTwo"`
+		}
+
+		two := uint8(2)
+		instance := &StructUnderTest{Two: &two}
+		actualBytes, err := Marshal(instance, ImplicitPresence())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x01, 0x02}, actualBytes)
+		assert.True(t, instance.HasTwo)
+	})
+
+	t.Run("verify bool flag is cleared from a nil slice", func(t *testing.T) {
+		type StructUnderTest struct {
+			HasTwo bool
+			Two    []byte `bcincludeif:"HasTwo" bcsliceprefix:"8"`
+		}
+
+		instance := &StructUnderTest{HasTwo: true}
+		actualBytes, err := Marshal(instance, ImplicitPresence())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x00}, actualBytes)
+		assert.False(t, instance.HasTwo)
+	})
+
+	t.Run("verify inverted bool flag is set from a nil pointer", func(t *testing.T) {
+		type StructUnderTest struct {
+			Absent bool
+			Two    *uint8 `bcincludeif:"Absent!=true"`
+		}
+
+		instance := &StructUnderTest{}
+		actualBytes, err := Marshal(instance, ImplicitPresence())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x01}, actualBytes)
+		assert.True(t, instance.Absent)
+	})
+
+	t.Run("verify flag in an ancestor is set from a present pointer in a nested struct", func(t *testing.T) {
+		type Body struct {
+			Two *uint8 `bcincludeif:"..Header.HasTwo"`
+		}
+
+		type StructUnderTest struct {
+			Header struct {
+				HasTwo bool
+			}
+			Body Body
+		}
+
+		two := uint8(2)
+		instance := &StructUnderTest{Body: Body{Two: &two}}
+		actualBytes, err := Marshal(instance, ImplicitPresence())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x01, 0x02}, actualBytes)
+		assert.True(t, instance.Header.HasTwo)
+	})
+
+	t.Run("verify uint flag is set to the condition value from a present pointer", func(t *testing.T) {
+		type StructUnderTest struct {
+			Mode uint8
+			Two  *uint8 `bcincludeif:"Mode==3"`
+		}
+
+		two := uint8(2)
+		instance := &StructUnderTest{Two: &two}
+		actualBytes, err := Marshal(instance, ImplicitPresence())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x03, 0x02}, actualBytes)
+	})
+
+	t.Run("verify uint flag which can not be derived errors", func(t *testing.T) {
+		type StructUnderTest struct {
+			Mode uint8
+			Two  *uint8 `bcincludeif:"Mode==3"`
+		}
+
+		instance := &StructUnderTest{Mode: 3}
+		_, err := Marshal(instance, ImplicitPresence())
+
+		assert.True(t, errors.Is(err, ErrPresenceMismatch))
+	})
+
+	t.Run("verify absolute references in nested structs are set", func(t *testing.T) {
+		type Nested struct {
+			Two *uint8 `bcincludeif:".HasTwo"`
+		}
+
+		type StructUnderTest struct {
+			HasTwo bool
+			Nested Nested
+		}
+
+		two := uint8(2)
+		instance := &StructUnderTest{Nested: Nested{Two: &two}}
+		actualBytes, err := Marshal(instance, ImplicitPresence())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x01, 0x02}, actualBytes)
+	})
+
+	t.Run("verify conflicting fields sharing a flag error", func(t *testing.T) {
+		type StructUnderTest struct {
+			HasOptional bool
+			Two         *uint8 `bcincludeif:"HasOptional"`
+			Three       *uint8 `bcincludeif:"HasOptional"`
+		}
+
+		two := uint8(2)
+		instance := &StructUnderTest{Two: &two}
+		_, err := Marshal(instance, ImplicitPresence())
+
+		assert.True(t, errors.Is(err, ErrPresenceMismatch))
+	})
+
+	t.Run("verify marshalling a non pointer errors", func(t *testing.T) {
+		type StructUnderTest struct {
+			HasTwo bool
+			Two    *uint8 `bcincludeif:"HasTwo"`
+		}
+
+		_, err := Marshal(StructUnderTest{}, ImplicitPresence())
+
+		assert.True(t, errors.Is(err, ErrPresenceMismatch))
+	})
+}
+
+func TestStrictPresence(t *testing.T) {
+	t.Run("verify consistent struct marshals", func(t *testing.T) {
+		type StructUnderTest struct {
+			HasTwo bool
+			Two    *uint8 `bcincludeif:"HasTwo"`
+		}
+
+		two := uint8(2)
+		instance := &StructUnderTest{HasTwo: true, Two: &two}
+		actualBytes, err := Marshal(instance, StrictPresence())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x01, 0x02}, actualBytes)
+	})
+
+	t.Run("verify set but excluded field errors", func(t *testing.T) {
+		type StructUnderTest struct {
+			HasTwo bool
+			Two    *uint8 `bcincludeif:"HasTwo"`
+		}
+
+		two := uint8(2)
+		instance := &StructUnderTest{HasTwo: false, Two: &two}
+		_, err := Marshal(instance, StrictPresence())
+
+		assert.True(t, errors.Is(err, ErrPresenceMismatch))
+		assert.Equal(t, "optional field presence does not match includeIf condition: field 'Two' is set but excluded", err.Error())
+	})
+
+	t.Run("verify nil but included field errors", func(t *testing.T) {
+		type StructUnderTest struct {
+			HasTwo bool
+			Two    []uint8 `bcincludeif:"HasTwo"`
+		}
+
+		instance := &StructUnderTest{HasTwo: true}
+		_, err := Marshal(instance, StrictPresence())
+
+		assert.True(t, errors.Is(err, ErrPresenceMismatch))
+		assert.Equal(t, "optional field presence does not match includeIf condition: field 'Two' is nil but included", err.Error())
+	})
+
+	t.Run("verify inconsistencies inside slices of structs error", func(t *testing.T) {
+		type Element struct {
+			HasTwo bool
+			Two    *uint8 `bcincludeif:"HasTwo"`
+		}
+
+		type StructUnderTest struct {
+			Elements []Element
+		}
+
+		instance := &StructUnderTest{Elements: []Element{{HasTwo: true}}}
+		_, err := Marshal(instance, StrictPresence())
+
+		assert.True(t, errors.Is(err, ErrPresenceMismatch))
+	})
+}
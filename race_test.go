@@ -0,0 +1,7 @@
+//go:build race
+
+package bytecodec
+
+// raceEnabled is set when tests are built with the race detector, under which sync.Pool drops values at random, so
+// tests that nothing is allocated do not hold.
+const raceEnabled = true
@@ -0,0 +1,123 @@
+package bytecodec
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/shimmeringbee/bytecodec/bitbuffer"
+)
+
+var ErrOutOfRange = errors.New("value out of range")
+
+type RangeError struct {
+	Field string
+	Value float64
+	Min   float64
+	Max   float64
+}
+
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("%v: field '%s' value %v outside of %v to %v", ErrOutOfRange, e.Field, e.Value, e.Min, e.Max)
+}
+
+func (e *RangeError) Unwrap() error {
+	return ErrOutOfRange
+}
+
+// wireRange returns the lowest wire value and the limit wire values must be below. The limit is a power of two, so
+// unlike the highest wire value it is exact as a float64 for 64 bit wire types.
+func (t ScaleTag) wireRange() (float64, float64) {
+	if t.Signed {
+		return -math.Pow(2, float64(t.Size-1)), math.Pow(2, float64(t.Size-1))
+	}
+
+	return 0, math.Pow(2, float64(t.Size))
+}
+
+func (t ScaleTag) toWire(value float64) float64 {
+	return t.Rounding.round((value - t.Offset) * t.Divisor / t.Multiplier)
+}
+
+func (t ScaleTag) fromWire(wire float64) float64 {
+	return wire*t.Multiplier/t.Divisor + t.Offset
+}
+
+func (r Rounding) round(value float64) float64 {
+	switch r {
+	case RoundHalfEven:
+		return math.RoundToEven(value)
+	case RoundFloor:
+		return math.Floor(value)
+	case RoundCeiling:
+		return math.Ceil(value)
+	case RoundTruncate:
+		return math.Trunc(value)
+	default:
+		return math.Round(value)
+	}
+}
+
+func marshalScaled(bb *bitbuffer.BitBuffer, name string, value reflect.Value, endian bitbuffer.Endian, tags reflect.StructTag) error {
+	scaleTag, err := tagScale(tags)
+	if err != nil {
+		return err
+	}
+
+	if kind := value.Kind(); kind != reflect.Float32 && kind != reflect.Float64 {
+		return fmt.Errorf("%w: field '%s' of type '%v' can not be scaled", ErrUnsupportedType, name, kind)
+	}
+
+	fieldValue := value.Float()
+	wire := scaleTag.toWire(fieldValue)
+	min, limit := scaleTag.wireRange()
+
+	if math.IsNaN(wire) || wire < min || wire >= limit {
+		rangeMin, rangeMax := scaleTag.fromWire(min), scaleTag.fromWire(limit-1)
+
+		if rangeMin > rangeMax {
+			rangeMin, rangeMax = rangeMax, rangeMin
+		}
+
+		return &RangeError{Field: name, Value: fieldValue, Min: rangeMin, Max: rangeMax}
+	}
+
+	if scaleTag.Signed {
+		return bb.WriteInt(int64(wire), endian, int(scaleTag.Size))
+	}
+
+	return bb.WriteUint(uint64(wire), endian, int(scaleTag.Size))
+}
+
+func unmarshalScaled(bb *bitbuffer.BitBuffer, name string, value reflect.Value, endian bitbuffer.Endian, tags reflect.StructTag) error {
+	scaleTag, err := tagScale(tags)
+	if err != nil {
+		return err
+	}
+
+	if kind := value.Kind(); kind != reflect.Float32 && kind != reflect.Float64 {
+		return fmt.Errorf("%w: field '%s' of type '%v' can not be scaled", ErrUnsupportedType, name, kind)
+	}
+
+	var wire float64
+
+	if scaleTag.Signed {
+		readValue, err := bb.ReadInt(endian, int(scaleTag.Size))
+		if err != nil {
+			return err
+		}
+
+		wire = float64(readValue)
+	} else {
+		readValue, err := bb.ReadUint(endian, int(scaleTag.Size))
+		if err != nil {
+			return err
+		}
+
+		wire = float64(readValue)
+	}
+
+	value.SetFloat(scaleTag.fromWire(wire))
+	return nil
+}
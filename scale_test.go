@@ -0,0 +1,135 @@
+package bytecodec
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScale(t *testing.T) {
+	t.Run("verify temperature in hundredths of a degree is marshalled as signed integer", func(t *testing.T) {
+		type StructUnderTest struct {
+			Temperature float64 `bcscale:"int16,div=100"`
+		}
+
+		instance := &StructUnderTest{Temperature: -21.5}
+		actualBytes, err := Marshal(instance)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x9a, 0xf7}, actualBytes)
+
+		actualStruct := &StructUnderTest{}
+		err = Unmarshal(actualBytes, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, instance, actualStruct)
+	})
+
+	t.Run("verify multiplier and offset are applied", func(t *testing.T) {
+		type StructUnderTest struct {
+			Percentage float32 `bcscale:"uint8,mul=0.5"`
+			Offset     float64 `bcscale:"uint8,offset=-40" bcendian:"big"`
+		}
+
+		instance := &StructUnderTest{Percentage: 50.5, Offset: -10}
+		actualBytes, err := Marshal(instance)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{101, 30}, actualBytes)
+
+		actualStruct := &StructUnderTest{}
+		err = Unmarshal(actualBytes, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, instance, actualStruct)
+	})
+
+	t.Run("verify rounding modes are obeyed", func(t *testing.T) {
+		type StructUnderTest struct {
+			Nearest  float64 `bcscale:"int8"`
+			Even     float64 `bcscale:"int8,round=even"`
+			Floor    float64 `bcscale:"int8,round=floor"`
+			Ceiling  float64 `bcscale:"int8,round=ceil"`
+			Truncate float64 `bcscale:"int8,round=truncate"`
+		}
+
+		instance := &StructUnderTest{Nearest: 2.5, Even: 2.5, Floor: -1.5, Ceiling: 1.2, Truncate: -1.7}
+		actualBytes, err := Marshal(instance)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{3, 2, 0xfe, 2, 0xff}, actualBytes)
+	})
+
+	t.Run("verify out of range values return a range error", func(t *testing.T) {
+		type StructUnderTest struct {
+			Voltage float64 `bcscale:"uint16,div=1000"`
+		}
+
+		_, err := Marshal(&StructUnderTest{Voltage: 65.536})
+
+		assert.True(t, errors.Is(err, ErrOutOfRange))
+
+		rangeErr := &RangeError{}
+		assert.True(t, errors.As(err, &rangeErr))
+		assert.Equal(t, "Voltage", rangeErr.Field)
+		assert.Equal(t, 65.536, rangeErr.Value)
+		assert.Equal(t, float64(0), rangeErr.Min)
+		assert.Equal(t, 65.535, rangeErr.Max)
+	})
+
+	t.Run("verify negative values for unsigned wire types return a range error", func(t *testing.T) {
+		type StructUnderTest struct {
+			Voltage float64 `bcscale:"uint16"`
+		}
+
+		_, err := Marshal(&StructUnderTest{Voltage: -1})
+
+		assert.True(t, errors.Is(err, ErrOutOfRange))
+	})
+
+	t.Run("verify the limits of 64 bit wire types return a range error", func(t *testing.T) {
+		type UnsignedUnderTest struct {
+			Value float64 `bcscale:"uint64"`
+		}
+
+		type SignedUnderTest struct {
+			Value float64 `bcscale:"int64"`
+		}
+
+		_, err := Marshal(&UnsignedUnderTest{Value: math.Pow(2, 64)})
+		assert.True(t, errors.Is(err, ErrOutOfRange))
+
+		_, err = Marshal(&SignedUnderTest{Value: math.Pow(2, 63)})
+		assert.True(t, errors.Is(err, ErrOutOfRange))
+
+		actualBytes, err := Marshal(&UnsignedUnderTest{Value: math.Pow(2, 63)})
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80}, actualBytes)
+
+		actualBytes, err = Marshal(&SignedUnderTest{Value: -math.Pow(2, 63)})
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80}, actualBytes)
+	})
+
+	t.Run("verify NaN returns a range error", func(t *testing.T) {
+		type StructUnderTest struct {
+			Voltage float64 `bcscale:"uint16"`
+		}
+
+		_, err := Marshal(&StructUnderTest{Voltage: math.NaN()})
+
+		assert.True(t, errors.Is(err, ErrOutOfRange))
+	})
+
+	t.Run("verify non float fields error", func(t *testing.T) {
+		type StructUnderTest struct {
+			Voltage uint16 `bcscale:"uint16"`
+		}
+
+		_, err := Marshal(&StructUnderTest{})
+
+		assert.True(t, errors.Is(err, ErrUnsupportedType))
+	})
+}
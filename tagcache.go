@@ -0,0 +1,46 @@
+package bytecodec
+
+import (
+	"reflect"
+	"sync"
+)
+
+type tagCacheKey struct {
+	name    string
+	value   string
+	present bool
+	lenient bool
+}
+
+type tagCacheEntry struct {
+	parsed interface{}
+	err    error
+}
+
+var tagCache = struct {
+	sync.RWMutex
+	entries map[tagCacheKey]tagCacheEntry
+}{entries: map[tagCacheKey]tagCacheEntry{}}
+
+// cachedTag returns the result of parsing the named tag, only calling parse the first time each value of the tag is
+// seen, as the same tags are parsed each time a type is marshalled or unmarshalled.
+func cachedTag(name string, tag reflect.StructTag, lenient bool, parse func() (interface{}, error)) (interface{}, error) {
+	value, tagPresent := tag.Lookup(name)
+	key := tagCacheKey{name: name, value: value, present: tagPresent, lenient: lenient}
+
+	tagCache.RLock()
+	entry, found := tagCache.entries[key]
+	tagCache.RUnlock()
+
+	if found {
+		return entry.parsed, entry.err
+	}
+
+	parsed, err := parse()
+
+	tagCache.Lock()
+	tagCache.entries[key] = tagCacheEntry{parsed: parsed, err: err}
+	tagCache.Unlock()
+
+	return parsed, err
+}
@@ -2,10 +2,12 @@ package bytecodec
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/shimmeringbee/bytecodec/bitbuffer"
 )
@@ -21,29 +23,98 @@ const (
 	TagStringType  = "bcstringtype"
 	TagIncludeIf   = "bcincludeif"
 	TagFieldWidth  = "bcfieldwidth"
-
-	BigEndianKeyword       = "big"
-	NullTerminationKeyword = "null"
+	TagIgnore      = "bcignore"
+	TagTime        = "bctime"
+	TagScale       = "bcscale"
+	TagBitmap      = "bcbitmap"
+	TagBit         = "bcbit"
+	TagBCD         = "bcbcd"
+	TagChecksum    = "bcchecksum"
+	TagLength      = "bclength"
+
+	BigEndianKeyword         = "big"
+	LittleEndianKeyword      = "little"
+	FalseKeyword             = "false"
+	NullTerminationKeyword   = "null"
+	PrefixTerminationKeyword = "prefix"
+	InvalidKeyword           = "invalid"
+	ReservedKeyword          = "reserved"
+	VarintKeyword            = "varint"
+	HighNibbleKeyword        = "high"
+	LowNibbleKeyword         = "low"
+	CountKeyword             = "count"
+
+	UnixEpochKeyword    = "unix"
+	ZigbeeEpochKeyword  = "zcl"
+	SecondsKeyword      = "s"
+	MillisecondsKeyword = "ms"
+	HundredthsKeyword   = "cs"
+
+	MultiplierKey = "mul"
+	DivisorKey    = "div"
+	OffsetKey     = "offset"
+	RoundingKey   = "round"
 )
 
-func tagEndianness(tag reflect.StructTag) bitbuffer.Endian {
-	if tag.Get(TagEndian) == BigEndianKeyword {
-		return bitbuffer.BigEndian
+func tagEndianness(tag reflect.StructTag, lenient bool) (bitbuffer.Endian, error) {
+	rawTag, tagPresent := tag.Lookup(TagEndian)
+
+	switch {
+	case !tagPresent || rawTag == LittleEndianKeyword:
+		return bitbuffer.LittleEndian, nil
+	case rawTag == BigEndianKeyword:
+		return bitbuffer.BigEndian, nil
+	case lenient:
+		return bitbuffer.LittleEndian, nil
+	default:
+		return bitbuffer.LittleEndian, fmt.Errorf("'%s' is not a valid %s, expected %s or %s", rawTag, TagEndian, BigEndianKeyword, LittleEndianKeyword)
+	}
+}
+
+// parseEndianKeyword sets the endian from a keyword in a comma separated tag, returning false if it is not an endian.
+func parseEndianKeyword(keyword string, endian *bitbuffer.Endian) bool {
+	switch keyword {
+	case BigEndianKeyword:
+		*endian = bitbuffer.BigEndian
+	case LittleEndianKeyword:
+		*endian = bitbuffer.LittleEndian
+	default:
+		return false
 	}
 
-	return bitbuffer.LittleEndian
+	return true
+}
+
+// parsePrefixSize parses the bit size of a length prefix.
+func parsePrefixSize(tagName string, raw string, lenient bool) (uint8, error) {
+	size, err := strconv.ParseUint(raw, 10, 8)
+	if err != nil || ((size == 0 || size > 64) && !lenient) {
+		return 0, fmt.Errorf("'%s' is not a valid %s size, expected 1 to 64 bits or %s", raw, tagName, VarintKeyword)
+	}
+
+	return uint8(size), nil
 }
 
 type SlicePrefixTag struct {
-	Size   uint8
-	Endian bitbuffer.Endian
+	Size    uint8
+	Endian  bitbuffer.Endian
+	Invalid bool
+	Varint  bool
 }
 
 func (l SlicePrefixTag) HasPrefix() bool {
-	return l.Size > 0
+	return l.Size > 0 || l.Varint
 }
 
-func tagSlicePrefix(tag reflect.StructTag) (l SlicePrefixTag, err error) {
+func tagSlicePrefix(tag reflect.StructTag, lenient bool) (SlicePrefixTag, error) {
+	parsed, err := cachedTag(TagSlicePrefix, tag, lenient, func() (interface{}, error) {
+		return parseSlicePrefix(tag, lenient)
+	})
+
+	return parsed.(SlicePrefixTag), err
+}
+
+func parseSlicePrefix(tag reflect.StructTag, lenient bool) (l SlicePrefixTag, err error) {
 	l.Endian = bitbuffer.LittleEndian
 
 	rawTag, tagPresent := tag.Lookup(TagSlicePrefix)
@@ -54,23 +125,28 @@ func tagSlicePrefix(tag reflect.StructTag) (l SlicePrefixTag, err error) {
 
 	splitTag := strings.Split(rawTag, ",")
 
-	if len(splitTag) < 1 {
-		return
+	if splitTag[0] == VarintKeyword {
+		l.Varint = true
+	} else if l.Size, err = parsePrefixSize(TagSlicePrefix, splitTag[0], lenient); err != nil {
+		return SlicePrefixTag{}, err
 	}
 
-	bitCount, err := strconv.Atoi(splitTag[0])
-	if err != nil {
-		return
+	for _, keyword := range splitTag[1:] {
+		switch {
+		case parseEndianKeyword(keyword, &l.Endian):
+		case keyword == InvalidKeyword:
+			l.Invalid = true
+		case !lenient:
+			return SlicePrefixTag{}, fmt.Errorf("'%s' is not a valid %s keyword", keyword, TagSlicePrefix)
+		}
 	}
 
-	l.Size = uint8(bitCount)
-
-	if len(splitTag) < 2 {
-		return
-	}
+	if l.Varint && l.Invalid {
+		if !lenient {
+			return SlicePrefixTag{}, fmt.Errorf("%s varint prefixes can not be invalid", TagSlicePrefix)
+		}
 
-	if splitTag[1] == BigEndianKeyword {
-		l.Endian = bitbuffer.BigEndian
+		l.Invalid = false
 	}
 
 	return
@@ -80,9 +156,19 @@ type StringTypeTag struct {
 	Termination StringTermination
 	Size        uint8
 	Endian      bitbuffer.Endian
+	Invalid     bool
+	Varint      bool
+}
+
+func tagStringType(tag reflect.StructTag, lenient bool) (StringTypeTag, error) {
+	parsed, err := cachedTag(TagStringType, tag, lenient, func() (interface{}, error) {
+		return parseStringType(tag, lenient)
+	})
+
+	return parsed.(StringTypeTag), err
 }
 
-func tagStringType(tag reflect.StructTag) (s StringTypeTag, err error) {
+func parseStringType(tag reflect.StructTag, lenient bool) (s StringTypeTag, err error) {
 	s.Termination = Prefix
 	s.Size = 8
 	s.Endian = bitbuffer.LittleEndian
@@ -95,28 +181,403 @@ func tagStringType(tag reflect.StructTag) (s StringTypeTag, err error) {
 
 	splitTag := strings.Split(rawTag, ",")
 
-	if splitTag[0] == NullTerminationKeyword {
+	switch splitTag[0] {
+	case NullTerminationKeyword:
 		s.Termination = Null
 		s.Size = 0
+	case PrefixTerminationKeyword:
+	default:
+		if !lenient {
+			return StringTypeTag{}, fmt.Errorf("'%s' is not a valid %s, expected %s or %s", splitTag[0], TagStringType, PrefixTerminationKeyword, NullTerminationKeyword)
+		}
 	}
 
 	if len(splitTag) <= 1 {
 		return
 	}
 
-	count, err := strconv.Atoi(splitTag[1])
-	if err != nil {
+	switch {
+	case splitTag[1] == VarintKeyword && s.Termination == Prefix:
+		s.Varint = true
+		s.Size = 0
+	case s.Termination == Null:
+		padding, err := strconv.ParseUint(splitTag[1], 10, 8)
+		if err != nil {
+			return StringTypeTag{}, fmt.Errorf("'%s' is not a valid %s padded length", splitTag[1], TagStringType)
+		}
+
+		s.Size = uint8(padding)
+	default:
+		if s.Size, err = parsePrefixSize(TagStringType, splitTag[1], lenient); err != nil {
+			return StringTypeTag{}, err
+		}
+	}
+
+	for _, keyword := range splitTag[2:] {
+		switch {
+		case parseEndianKeyword(keyword, &s.Endian):
+		case keyword == InvalidKeyword && (s.Termination == Prefix || lenient):
+			s.Invalid = true
+		case !lenient:
+			return StringTypeTag{}, fmt.Errorf("'%s' is not a valid %s keyword", keyword, TagStringType)
+		}
+	}
+
+	if s.Varint && s.Invalid {
+		if !lenient {
+			return StringTypeTag{}, fmt.Errorf("%s varint prefixes can not be invalid", TagStringType)
+		}
+
+		s.Invalid = false
+	}
+
+	return
+}
+
+func nullableString(ptrType reflect.Type, tag reflect.StructTag, lenient bool) (StringTypeTag, bool) {
+	if ptrType.Elem().Kind() != reflect.String {
+		return StringTypeTag{}, false
+	}
+
+	stringTag, err := tagStringType(tag, lenient)
+
+	return stringTag, err == nil && stringTag.Termination == Prefix && stringTag.Invalid
+}
+
+type TimeTag struct {
+	Present    bool
+	Epoch      time.Time
+	Resolution time.Duration
+	Size       uint8
+	Invalid    bool
+}
+
+func tagTime(tag reflect.StructTag) (TimeTag, error) {
+	parsed, err := cachedTag(TagTime, tag, false, func() (interface{}, error) {
+		return parseTime(tag)
+	})
+
+	return parsed.(TimeTag), err
+}
+
+func parseTime(tag reflect.StructTag) (t TimeTag, err error) {
+	t.Epoch = UnixEpoch()
+	t.Resolution = time.Second
+	t.Size = 32
+
+	rawTag, tagPresent := tag.Lookup(TagTime)
+
+	if !tagPresent {
 		return
 	}
 
-	s.Size = uint8(count)
+	t.Present = true
+
+	for _, keyword := range strings.Split(rawTag, ",") {
+		switch keyword {
+		case "":
+		case UnixEpochKeyword:
+			t.Epoch = UnixEpoch()
+		case ZigbeeEpochKeyword:
+			t.Epoch = ZigbeeEpoch()
+		case SecondsKeyword:
+			t.Resolution = time.Second
+		case MillisecondsKeyword:
+			t.Resolution = time.Millisecond
+		case HundredthsKeyword:
+			t.Resolution = 10 * time.Millisecond
+		case InvalidKeyword:
+			t.Invalid = true
+		default:
+			size, err := strconv.ParseUint(keyword, 10, 8)
+			if err != nil || size == 0 || size > 64 {
+				return TimeTag{}, fmt.Errorf("'%s' is not a valid %s keyword", keyword, TagTime)
+			}
+
+			t.Size = uint8(size)
+		}
+	}
+
+	return
+}
 
-	if len(splitTag) <= 2 {
+type BitmapTag struct {
+	Size   uint8
+	Endian bitbuffer.Endian
+}
+
+func tagBitmap(tag reflect.StructTag, lenient bool) (b BitmapTag, err error) {
+	if b.Endian, err = tagEndianness(tag, lenient); err != nil {
+		return BitmapTag{}, err
+	}
+
+	rawTag, tagPresent := tag.Lookup(TagBitmap)
+
+	if !tagPresent {
+		return BitmapTag{}, fmt.Errorf("%s tag is required on bitmap marker", TagBitmap)
+	}
+
+	size, err := strconv.ParseUint(rawTag, 10, 8)
+	if err != nil || size == 0 || size > 64 || size%8 != 0 {
+		return BitmapTag{}, fmt.Errorf("'%s' is not a valid %s size", rawTag, TagBitmap)
+	}
+
+	b.Size = uint8(size)
+
+	return
+}
+
+type BitTag struct {
+	Present  bool
+	Reserved bool
+	Position uint8
+}
+
+func tagBit(tag reflect.StructTag) (b BitTag, err error) {
+	rawTag, tagPresent := tag.Lookup(TagBit)
+
+	if !tagPresent {
 		return
 	}
 
-	if splitTag[2] == BigEndianKeyword {
-		s.Endian = bitbuffer.BigEndian
+	b.Present = true
+
+	if rawTag == ReservedKeyword {
+		b.Reserved = true
+		return
+	}
+
+	position, err := strconv.ParseUint(rawTag, 10, 8)
+	if err != nil || position >= 64 {
+		return BitTag{}, fmt.Errorf("'%s' is not a valid %s position", rawTag, TagBit)
+	}
+
+	b.Position = uint8(position)
+
+	return
+}
+
+type BCDTag struct {
+	Digits uint8
+	Order  bitbuffer.NibbleOrder
+}
+
+func tagBCD(tag reflect.StructTag) (BCDTag, error) {
+	parsed, err := cachedTag(TagBCD, tag, false, func() (interface{}, error) {
+		return parseBCD(tag)
+	})
+
+	return parsed.(BCDTag), err
+}
+
+func parseBCD(tag reflect.StructTag) (b BCDTag, err error) {
+	b.Order = bitbuffer.HighNibbleFirst
+
+	rawTag, tagPresent := tag.Lookup(TagBCD)
+
+	if !tagPresent {
+		return
+	}
+
+	splitTag := strings.Split(rawTag, ",")
+
+	digits, err := strconv.ParseUint(splitTag[0], 10, 8)
+	if err != nil || digits == 0 {
+		return BCDTag{}, fmt.Errorf("'%s' is not a valid %s digit count", splitTag[0], TagBCD)
+	}
+
+	b.Digits = uint8(digits)
+
+	for _, keyword := range splitTag[1:] {
+		switch keyword {
+		case HighNibbleKeyword:
+			b.Order = bitbuffer.HighNibbleFirst
+		case LowNibbleKeyword:
+			b.Order = bitbuffer.LowNibbleFirst
+		default:
+			return BCDTag{}, fmt.Errorf("'%s' is not a valid %s keyword", keyword, TagBCD)
+		}
+	}
+
+	return
+}
+
+type ChecksumTag struct {
+	Present    bool
+	Algorithm  string
+	StartField string
+}
+
+func tagChecksum(tag reflect.StructTag) (ChecksumTag, error) {
+	parsed, err := cachedTag(TagChecksum, tag, false, func() (interface{}, error) {
+		return parseChecksum(tag)
+	})
+
+	return parsed.(ChecksumTag), err
+}
+
+func parseChecksum(tag reflect.StructTag) (c ChecksumTag, err error) {
+	rawTag, tagPresent := tag.Lookup(TagChecksum)
+
+	if !tagPresent {
+		return
+	}
+
+	splitTag := strings.Split(rawTag, ",")
+
+	if len(splitTag) != 2 || splitTag[0] == "" || splitTag[1] == "" {
+		return ChecksumTag{}, fmt.Errorf("'%s' is not a valid %s, expected algorithm and start field", rawTag, TagChecksum)
+	}
+
+	c.Present = true
+	c.Algorithm = splitTag[0]
+	c.StartField = splitTag[1]
+
+	return
+}
+
+type LengthTag struct {
+	Present bool
+	Field   string
+	Count   bool
+}
+
+func tagLength(tag reflect.StructTag) (LengthTag, error) {
+	parsed, err := cachedTag(TagLength, tag, false, func() (interface{}, error) {
+		return parseLength(tag)
+	})
+
+	return parsed.(LengthTag), err
+}
+
+func parseLength(tag reflect.StructTag) (l LengthTag, err error) {
+	rawTag, tagPresent := tag.Lookup(TagLength)
+
+	if !tagPresent {
+		return
+	}
+
+	splitTag := strings.Split(rawTag, ",")
+
+	if splitTag[0] == "" {
+		return LengthTag{}, fmt.Errorf("'%s' is not a valid %s, expected field name", rawTag, TagLength)
+	}
+
+	l.Present = true
+	l.Field = splitTag[0]
+
+	for _, keyword := range splitTag[1:] {
+		switch keyword {
+		case CountKeyword:
+			l.Count = true
+		default:
+			return LengthTag{}, fmt.Errorf("'%s' is not a valid %s keyword", keyword, TagLength)
+		}
+	}
+
+	return
+}
+
+type Rounding uint8
+
+const (
+	RoundHalfAwayFromZero Rounding = 0
+	RoundHalfEven         Rounding = 1
+	RoundFloor            Rounding = 2
+	RoundCeiling          Rounding = 3
+	RoundTruncate         Rounding = 4
+)
+
+var roundingKeywords = map[string]Rounding{
+	"nearest":  RoundHalfAwayFromZero,
+	"even":     RoundHalfEven,
+	"floor":    RoundFloor,
+	"ceil":     RoundCeiling,
+	"truncate": RoundTruncate,
+}
+
+type ScaleTag struct {
+	Signed     bool
+	Size       uint8
+	Multiplier float64
+	Divisor    float64
+	Offset     float64
+	Rounding   Rounding
+}
+
+var ScaleWireTypeRegex = regexp.MustCompile(`^(u?int)([0-9]+)$`)
+
+func tagScale(tag reflect.StructTag) (ScaleTag, error) {
+	parsed, err := cachedTag(TagScale, tag, false, func() (interface{}, error) {
+		return parseScale(tag)
+	})
+
+	return parsed.(ScaleTag), err
+}
+
+func parseScale(tag reflect.StructTag) (s ScaleTag, err error) {
+	s.Multiplier = 1
+	s.Divisor = 1
+
+	rawTag, tagPresent := tag.Lookup(TagScale)
+
+	if !tagPresent {
+		return
+	}
+
+	splitTag := strings.Split(rawTag, ",")
+
+	matches := ScaleWireTypeRegex.FindStringSubmatch(splitTag[0])
+	if matches == nil {
+		return ScaleTag{}, fmt.Errorf("'%s' is not a valid %s wire type", splitTag[0], TagScale)
+	}
+
+	size, err := strconv.ParseUint(matches[2], 10, 8)
+	if err != nil || size == 0 || size > 64 {
+		return ScaleTag{}, fmt.Errorf("'%s' is not a valid %s wire type", splitTag[0], TagScale)
+	}
+
+	s.Signed = matches[1] == "int"
+	s.Size = uint8(size)
+
+	for _, parameter := range splitTag[1:] {
+		splitParameter := strings.SplitN(parameter, "=", 2)
+
+		if len(splitParameter) != 2 {
+			return ScaleTag{}, fmt.Errorf("'%s' is not a valid %s parameter", parameter, TagScale)
+		}
+
+		key, value := splitParameter[0], splitParameter[1]
+
+		switch key {
+		case MultiplierKey, DivisorKey, OffsetKey:
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+				return ScaleTag{}, fmt.Errorf("'%s' is not a valid number for %s %s", value, TagScale, key)
+			}
+
+			switch key {
+			case MultiplierKey:
+				s.Multiplier = number
+			case DivisorKey:
+				s.Divisor = number
+			case OffsetKey:
+				s.Offset = number
+			}
+		case RoundingKey:
+			rounding, found := roundingKeywords[value]
+			if !found {
+				return ScaleTag{}, fmt.Errorf("'%s' is not a valid %s rounding", value, TagScale)
+			}
+
+			s.Rounding = rounding
+		default:
+			return ScaleTag{}, fmt.Errorf("'%s' is not a valid %s parameter", key, TagScale)
+		}
+	}
+
+	if s.Multiplier == 0 || s.Divisor == 0 {
+		return ScaleTag{}, fmt.Errorf("%s multiplier and divisor must not be zero", TagScale)
 	}
 
 	return
@@ -129,8 +590,12 @@ const (
 	NotEqual IncludeIfOperation = 0x01
 )
 
+// IncludeIfTag is a parsed bcincludeif condition. A path starting with a single dot is resolved from the root, a path
+// without a leading dot from the struct holding the field, and each further leading dot resolves from one struct
+// further out, recorded in Ancestor. Parts of FieldPath may index into arrays and slices, such as "Items[2]".
 type IncludeIfTag struct {
 	Relative  bool
+	Ancestor  int
 	FieldPath []string
 
 	Operation IncludeIfOperation
@@ -138,9 +603,19 @@ type IncludeIfTag struct {
 	Value string
 }
 
-var IncludeIfRegex = regexp.MustCompile(`^([a-zA-Z0-9.]+)(!=|==)?(.*)$`)
+var IncludeIfRegex = regexp.MustCompile(`^([a-zA-Z0-9_.\[\]]+)(!=|==)?(.*)$`)
+
+var includeIfPartRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*(\[[0-9]+\])*$`)
 
-func tagIncludeIf(tag reflect.StructTag) (i IncludeIfTag, err error) {
+func tagIncludeIf(tag reflect.StructTag) (IncludeIfTag, error) {
+	parsed, err := cachedTag(TagIncludeIf, tag, false, func() (interface{}, error) {
+		return parseIncludeIf(tag)
+	})
+
+	return parsed.(IncludeIfTag), err
+}
+
+func parseIncludeIf(tag reflect.StructTag) (i IncludeIfTag, err error) {
 	rawTag, tagPresent := tag.Lookup(TagIncludeIf)
 
 	if !tagPresent {
@@ -148,21 +623,29 @@ func tagIncludeIf(tag reflect.StructTag) (i IncludeIfTag, err error) {
 	}
 
 	matches := IncludeIfRegex.FindAllSubmatch([]byte(rawTag), -1)
+	if matches == nil {
+		return IncludeIfTag{}, fmt.Errorf("'%s' is not a valid %s", rawTag, TagIncludeIf)
+	}
 
 	path := string(matches[0][1])
 	operator := string(matches[0][2])
 
 	i.Value = string(matches[0][3])
-	i.Relative = path[0] != '.'
 
-	pathParts := strings.Split(path, ".")
-	partStart := 1
+	dots := len(path) - len(strings.TrimLeft(path, "."))
+	i.Relative = dots != 1
 
-	if i.Relative {
-		partStart = 0
+	if dots > 1 {
+		i.Ancestor = dots - 1
 	}
 
-	i.FieldPath = pathParts[partStart:]
+	i.FieldPath = strings.Split(path[dots:], ".")
+
+	for _, part := range i.FieldPath {
+		if !includeIfPartRegex.MatchString(part) {
+			return IncludeIfTag{}, fmt.Errorf("'%s' is not a valid %s path", path, TagIncludeIf)
+		}
+	}
 
 	switch operator {
 	case "==", "":
@@ -183,6 +666,7 @@ func (i IncludeIfTag) HasIncludeIf() bool {
 type FieldWidthTag struct {
 	Default  bool
 	BitWidth int
+	Varint   bool
 }
 
 func (t FieldWidthTag) Width(defaultWidth int) int {
@@ -193,18 +677,30 @@ func (t FieldWidthTag) Width(defaultWidth int) int {
 	return t.BitWidth
 }
 
-func tagFieldWidth(tag reflect.StructTag) (t FieldWidthTag, err error) {
+// varintKind returns if a varint field width can apply to a kind, which must be an integer or hold integers.
+func varintKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Array, reflect.Slice, reflect.Ptr:
+		return true
+	default:
+		return isUint(kind) || isInt(kind)
+	}
+}
+
+func tagFieldWidth(tag reflect.StructTag, lenient bool) (t FieldWidthTag, err error) {
 	rawTag, tagPresent := tag.Lookup(TagFieldWidth)
 
 	if !tagPresent {
 		t.Default = true
+	} else if rawTag == VarintKeyword {
+		t.Varint = true
 	} else {
 		t.Default = false
 
 		width, err := strconv.ParseInt(rawTag, 10, 8)
 
-		if err != nil {
-			return t, err
+		if err != nil || ((width <= 0 || width > 64) && !lenient) {
+			return t, fmt.Errorf("'%s' is not a valid %s, expected 1 to 64 bits or %s", rawTag, TagFieldWidth, VarintKeyword)
 		}
 
 		t.BitWidth = int(width)
@@ -212,3 +708,67 @@ func tagFieldWidth(tag reflect.StructTag) (t FieldWidthTag, err error) {
 
 	return
 }
+
+// Defaults is a zero sized marker, declared as a blank field such as _ Defaults with a bcendian:"big" tag, whose
+// bcendian and bcstringtype tags become the defaults for every other field of the struct and any nested structs. A tag
+// on a field itself still takes precedence, and the marker is never read from or written to the wire.
+type Defaults struct{}
+
+var defaultsType = reflect.TypeOf(Defaults{})
+
+var defaultableTags = []string{TagEndian, TagStringType}
+
+func isDefaultsMarker(field reflect.StructField) bool {
+	return field.Type == defaultsType
+}
+
+func isFlattened(field reflect.StructField) bool {
+	return field.Anonymous && field.Type.Kind() == reflect.Struct && !isDefaultsMarker(field) && !isBitmapMarker(field)
+}
+
+func structDefaults(structType reflect.Type, inherited reflect.StructTag) reflect.StructTag {
+	defaults := inherited
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		if isDefaultsMarker(field) {
+			defaults = withDefaults(filterTags(field.Tag, defaultableTags), defaults)
+		}
+	}
+
+	return defaults
+}
+
+func withDefaults(tag reflect.StructTag, defaults reflect.StructTag) reflect.StructTag {
+	if len(defaults) == 0 {
+		return tag
+	}
+
+	if len(tag) == 0 {
+		return defaults
+	}
+
+	return tag + " " + defaults
+}
+
+func filterTags(tag reflect.StructTag, keys []string) reflect.StructTag {
+	var filtered []string
+
+	for _, key := range keys {
+		if value, present := tag.Lookup(key); present {
+			filtered = append(filtered, key+":"+strconv.Quote(value))
+		}
+	}
+
+	return reflect.StructTag(strings.Join(filtered, " "))
+}
+
+func isIgnored(field reflect.StructField) bool {
+	if field.PkgPath != "" && !isFlattened(field) {
+		return true
+	}
+
+	rawTag, tagPresent := field.Tag.Lookup(TagIgnore)
+	return tagPresent && rawTag != FalseKeyword
+}
@@ -1,7 +1,9 @@
 package bytecodec
 
 import (
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/shimmeringbee/bytecodec/bitbuffer"
 	"github.com/stretchr/testify/assert"
@@ -10,23 +12,61 @@ import (
 func TestTagsEndian(t *testing.T) {
 	t.Run("verifies that unannotated tag returns little endian", func(t *testing.T) {
 		expectedValue := bitbuffer.LittleEndian
-		actualValue := tagEndianness("")
+		actualValue, err := tagEndianness("", false)
 
+		assert.NoError(t, err)
 		assert.Equal(t, expectedValue, actualValue)
 	})
 
 	t.Run("verifies that annotated tag with little endian returns little endian", func(t *testing.T) {
 		expectedValue := bitbuffer.LittleEndian
-		actualValue := tagEndianness(`bcendian:"little"`)
+		actualValue, err := tagEndianness(`bcendian:"little"`, false)
 
+		assert.NoError(t, err)
 		assert.Equal(t, expectedValue, actualValue)
 	})
 
 	t.Run("verifies that annotated tag with big endian returns big endian", func(t *testing.T) {
 		expectedValue := bitbuffer.BigEndian
-		actualValue := tagEndianness(`bcendian:"big"`)
+		actualValue, err := tagEndianness(`bcendian:"big"`, false)
+
+		assert.NoError(t, err)
 		assert.Equal(t, expectedValue, actualValue)
 	})
+
+	t.Run("verifies that an unknown endian returns an error", func(t *testing.T) {
+		_, err := tagEndianness(`bcendian:"Big"`, false)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("verifies that an unknown endian is little endian when lenient", func(t *testing.T) {
+		actualValue, err := tagEndianness(`bcendian:"Big"`, true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, bitbuffer.LittleEndian, actualValue)
+	})
+
+	t.Run("verifies that the LenientTags option applies to marshalling, unmarshalling and validation", func(t *testing.T) {
+		type StructUnderTest struct {
+			One uint16 `bcendian:"Big"`
+		}
+
+		_, err := Marshal(&StructUnderTest{One: 0x0102})
+		assert.Error(t, err)
+
+		actualBytes, err := Marshal(&StructUnderTest{One: 0x0102}, LenientTags())
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x02, 0x01}, actualBytes)
+
+		actualStruct := &StructUnderTest{}
+		assert.Error(t, Unmarshal(actualBytes, actualStruct))
+		assert.NoError(t, Unmarshal(actualBytes, actualStruct, LenientTags()))
+		assert.Equal(t, uint16(0x0102), actualStruct.One)
+
+		assert.Error(t, Validate(StructUnderTest{}))
+		assert.NoError(t, Validate(StructUnderTest{}, LenientTags()))
+	})
 }
 
 func TestTagsArrayPrefix(t *testing.T) {
@@ -35,7 +75,7 @@ func TestTagsArrayPrefix(t *testing.T) {
 			Size:   0,
 			Endian: bitbuffer.LittleEndian,
 		}
-		actualValue, err := tagSlicePrefix("")
+		actualValue, err := tagSlicePrefix("", false)
 
 		assert.NoError(t, err)
 		assert.False(t, actualValue.HasPrefix())
@@ -47,7 +87,7 @@ func TestTagsArrayPrefix(t *testing.T) {
 			Size:   8,
 			Endian: bitbuffer.LittleEndian,
 		}
-		actualValue, err := tagSlicePrefix(`bcsliceprefix:"8"`)
+		actualValue, err := tagSlicePrefix(`bcsliceprefix:"8"`, false)
 
 		assert.NoError(t, err)
 		assert.True(t, actualValue.HasPrefix())
@@ -59,18 +99,72 @@ func TestTagsArrayPrefix(t *testing.T) {
 			Size:   16,
 			Endian: bitbuffer.BigEndian,
 		}
-		actualValue, err := tagSlicePrefix(`bcsliceprefix:"16,big"`)
+		actualValue, err := tagSlicePrefix(`bcsliceprefix:"16,big"`, false)
 
 		assert.NoError(t, err)
 		assert.True(t, actualValue.HasPrefix())
 		assert.Equal(t, expectedValue, actualValue)
 	})
 
+	t.Run("verifies that annotated with invalid keyword in any order after size", func(t *testing.T) {
+		expectedValue := SlicePrefixTag{
+			Size:    16,
+			Endian:  bitbuffer.BigEndian,
+			Invalid: true,
+		}
+		actualValue, err := tagSlicePrefix(`bcsliceprefix:"16,invalid,big"`, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedValue, actualValue)
+	})
+
+	t.Run("verifies that annotated with varint", func(t *testing.T) {
+		expectedValue := SlicePrefixTag{
+			Endian: bitbuffer.LittleEndian,
+			Varint: true,
+		}
+		actualValue, err := tagSlicePrefix(`bcsliceprefix:"varint"`, false)
+
+		assert.NoError(t, err)
+		assert.True(t, actualValue.HasPrefix())
+		assert.Equal(t, expectedValue, actualValue)
+	})
+
+	t.Run("verify that varint with invalid keyword returns error unless lenient", func(t *testing.T) {
+		_, err := tagSlicePrefix(`bcsliceprefix:"varint,invalid"`, false)
+		assert.Error(t, err)
+
+		actualValue, err := tagSlicePrefix(`bcsliceprefix:"varint,invalid"`, true)
+		assert.NoError(t, err)
+		assert.False(t, actualValue.Invalid)
+	})
+
 	t.Run("verify that parse of invalid bit count returns error", func(t *testing.T) {
-		_, err := tagSlicePrefix(`bcsliceprefix:"SPOON,big"`)
+		_, err := tagSlicePrefix(`bcsliceprefix:"SPOON,big"`, false)
 
 		assert.Error(t, err)
 	})
+
+	t.Run("verify that unknown keywords and out of range sizes return errors", func(t *testing.T) {
+		for _, tag := range []reflect.StructTag{`bcsliceprefix:"8,Big"`, `bcsliceprefix:"0"`, `bcsliceprefix:"65"`, `bcsliceprefix:"-8"`} {
+			_, err := tagSlicePrefix(tag, false)
+			assert.Error(t, err, tag)
+		}
+	})
+
+	t.Run("verify that unknown keywords are ignored when lenient", func(t *testing.T) {
+		actualValue, err := tagSlicePrefix(`bcsliceprefix:"8,Big"`, true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, SlicePrefixTag{Size: 8, Endian: bitbuffer.LittleEndian}, actualValue)
+	})
+
+	t.Run("verify that little endian is recognised", func(t *testing.T) {
+		actualValue, err := tagSlicePrefix(`bcsliceprefix:"16,little"`, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, SlicePrefixTag{Size: 16, Endian: bitbuffer.LittleEndian}, actualValue)
+	})
 }
 
 func TestTagsString(t *testing.T) {
@@ -80,7 +174,7 @@ func TestTagsString(t *testing.T) {
 			Size:        8,
 			Endian:      bitbuffer.LittleEndian,
 		}
-		actualValue, err := tagStringType("")
+		actualValue, err := tagStringType("", false)
 
 		assert.NoError(t, err)
 		assert.Equal(t, expectedValue, actualValue)
@@ -92,7 +186,20 @@ func TestTagsString(t *testing.T) {
 			Size:        16,
 			Endian:      bitbuffer.BigEndian,
 		}
-		actualValue, err := tagStringType(`bcstringtype:"prefix,16,big"`)
+		actualValue, err := tagStringType(`bcstringtype:"prefix,16,big"`, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedValue, actualValue)
+	})
+
+	t.Run("verifies that annotated with a prefix and invalid keyword", func(t *testing.T) {
+		expectedValue := StringTypeTag{
+			Termination: Prefix,
+			Size:        8,
+			Endian:      bitbuffer.LittleEndian,
+			Invalid:     true,
+		}
+		actualValue, err := tagStringType(`bcstringtype:"prefix,8,invalid"`, false)
 
 		assert.NoError(t, err)
 		assert.Equal(t, expectedValue, actualValue)
@@ -104,7 +211,7 @@ func TestTagsString(t *testing.T) {
 			Size:        0,
 			Endian:      bitbuffer.LittleEndian,
 		}
-		actualValue, err := tagStringType(`bcstringtype:"null"`)
+		actualValue, err := tagStringType(`bcstringtype:"null"`, false)
 
 		assert.NoError(t, err)
 		assert.Equal(t, expectedValue, actualValue)
@@ -116,23 +223,49 @@ func TestTagsString(t *testing.T) {
 			Size:        8,
 			Endian:      bitbuffer.LittleEndian,
 		}
-		actualValue, err := tagStringType(`bcstringtype:"null,8"`)
+		actualValue, err := tagStringType(`bcstringtype:"null,8"`, false)
 
 		assert.NoError(t, err)
 		assert.Equal(t, expectedValue, actualValue)
 	})
 
 	t.Run("verifies that annotated with null, and an invalid padding size", func(t *testing.T) {
-		_, err := tagStringType(`bcstringtype:"null,SPOON,big"`)
+		_, err := tagStringType(`bcstringtype:"null,SPOON,big"`, false)
 
 		assert.Error(t, err)
 	})
 
+	t.Run("verifies that annotated with prefix and varint", func(t *testing.T) {
+		expectedValue := StringTypeTag{
+			Termination: Prefix,
+			Endian:      bitbuffer.LittleEndian,
+			Varint:      true,
+		}
+		actualValue, err := tagStringType(`bcstringtype:"prefix,varint"`, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedValue, actualValue)
+	})
+
 	t.Run("verifies that annotated with prefix, and an invalid padding size", func(t *testing.T) {
-		_, err := tagStringType(`bcstringtype:"prefix,SPOON,big"`)
+		_, err := tagStringType(`bcstringtype:"prefix,SPOON,big"`, false)
 
 		assert.Error(t, err)
 	})
+
+	t.Run("verifies that unknown terminations, keywords and sizes return errors", func(t *testing.T) {
+		for _, tag := range []reflect.StructTag{`bcstringtype:"nul"`, `bcstringtype:"prefix,8,bigg"`, `bcstringtype:"prefix,0"`, `bcstringtype:"null,8,invalid"`} {
+			_, err := tagStringType(tag, false)
+			assert.Error(t, err, tag)
+		}
+	})
+
+	t.Run("verifies that unknown terminations and keywords are ignored when lenient", func(t *testing.T) {
+		actualValue, err := tagStringType(`bcstringtype:"nul,16,bigg"`, true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, StringTypeTag{Termination: Prefix, Size: 16, Endian: bitbuffer.LittleEndian}, actualValue)
+	})
 }
 
 func TestTagsIncludeIf(t *testing.T) {
@@ -219,11 +352,43 @@ func TestTagsIncludeIf(t *testing.T) {
 		}, tag)
 		assert.NoError(t, err)
 	})
+
+	t.Run("verifies that each further leading dot refers to an ancestor", func(t *testing.T) {
+		tag, err := tagIncludeIf(`bcincludeif:"...Header.Flags==2"`)
+
+		assert.Equal(t, IncludeIfTag{
+			Relative:  true,
+			Ancestor:  2,
+			FieldPath: []string{"Header", "Flags"},
+			Operation: Equal,
+			Value:     "2",
+		}, tag)
+		assert.NoError(t, err)
+	})
+
+	t.Run("verifies that path parts may index arrays and slices", func(t *testing.T) {
+		tag, err := tagIncludeIf(`bcincludeif:"Items[2][10].Flag"`)
+
+		assert.Equal(t, IncludeIfTag{
+			Relative:  true,
+			FieldPath: []string{"Items[2][10]", "Flag"},
+			Operation: Equal,
+			Value:     "",
+		}, tag)
+		assert.NoError(t, err)
+	})
+
+	t.Run("fails if a path part is not a field name with indexes", func(t *testing.T) {
+		for _, path := range []string{".", "..", "One..Two", "Items[]", "Items[a]", "[1]", "Items]1["} {
+			_, err := tagIncludeIf(reflect.StructTag(`bcincludeif:"` + path + `"`))
+			assert.Error(t, err, path)
+		}
+	})
 }
 
 func TestFieldWidthTag(t *testing.T) {
 	t.Run("missing tag passes default width through", func(t *testing.T) {
-		actualValue, err := tagFieldWidth(``)
+		actualValue, err := tagFieldWidth(``, false)
 
 		assert.NoError(t, err)
 		assert.True(t, actualValue.Default)
@@ -234,7 +399,7 @@ func TestFieldWidthTag(t *testing.T) {
 	})
 
 	t.Run("provided tag overrides default width", func(t *testing.T) {
-		actualValue, err := tagFieldWidth(`bcfieldwidth:"15"`)
+		actualValue, err := tagFieldWidth(`bcfieldwidth:"15"`, false)
 
 		assert.NoError(t, err)
 		assert.False(t, actualValue.Default)
@@ -244,9 +409,262 @@ func TestFieldWidthTag(t *testing.T) {
 		assert.Equal(t, expectedValue, actualValue.Width(8))
 	})
 
+	t.Run("varint tag is parsed", func(t *testing.T) {
+		actualValue, err := tagFieldWidth(`bcfieldwidth:"varint"`, false)
+
+		assert.NoError(t, err)
+		assert.True(t, actualValue.Varint)
+	})
+
 	t.Run("tag with invalid bit count errors", func(t *testing.T) {
-		_, err := tagFieldWidth(`bcfieldwidth:"SPOON"`)
+		_, err := tagFieldWidth(`bcfieldwidth:"SPOON"`, false)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("tag with out of range bit count errors unless lenient", func(t *testing.T) {
+		_, err := tagFieldWidth(`bcfieldwidth:"0"`, false)
+		assert.Error(t, err)
+
+		_, err = tagFieldWidth(`bcfieldwidth:"0"`, true)
+		assert.NoError(t, err)
+	})
+}
+
+func TestTagsDefaults(t *testing.T) {
+	t.Run("verifies that defaults marker tags are collected, ignoring non defaultable tags", func(t *testing.T) {
+		type StructUnderTest struct {
+			_   Defaults `bcendian:"big" bcincludeif:"One"`
+			One bool
+		}
+
+		actualValue := structDefaults(reflect.TypeOf(StructUnderTest{}), "")
+
+		assert.Equal(t, reflect.StructTag(`bcendian:"big"`), actualValue)
+	})
+
+	t.Run("verifies that defaults marker tags override inherited defaults", func(t *testing.T) {
+		type StructUnderTest struct {
+			_ Defaults `bcendian:"little"`
+		}
+
+		actualValue := structDefaults(reflect.TypeOf(StructUnderTest{}), `bcendian:"big"`)
+
+		actualEndian, err := tagEndianness(actualValue, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, bitbuffer.LittleEndian, actualEndian)
+	})
+
+	t.Run("verifies that field tags take precedence over defaults", func(t *testing.T) {
+		actualValue := withDefaults(`bcendian:"little"`, `bcendian:"big" bcstringtype:"null"`)
+
+		actualEndian, err := tagEndianness(actualValue, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, bitbuffer.LittleEndian, actualEndian)
+		assert.Equal(t, "null", actualValue.Get(TagStringType))
+	})
+}
+
+func TestTagsIgnore(t *testing.T) {
+	type StructUnderTest struct {
+		Exported   uint8
+		Ignored    uint8 `bcignore:""`
+		NotIgnored uint8 `bcignore:"false"`
+		unexported uint8
+	}
+
+	structType := reflect.TypeOf(StructUnderTest{})
+
+	t.Run("verifies that exported fields are not ignored", func(t *testing.T) {
+		assert.False(t, isIgnored(structType.Field(0)))
+	})
+
+	t.Run("verifies that fields with an ignore tag are ignored", func(t *testing.T) {
+		assert.True(t, isIgnored(structType.Field(1)))
+	})
+
+	t.Run("verifies that fields with an ignore tag of false are not ignored", func(t *testing.T) {
+		assert.False(t, isIgnored(structType.Field(2)))
+	})
+
+	t.Run("verifies that unexported fields are ignored", func(t *testing.T) {
+		assert.True(t, isIgnored(structType.Field(3)))
+	})
+}
+
+func TestTagsTime(t *testing.T) {
+	t.Run("verifies that unannotated tag is not present with defaults", func(t *testing.T) {
+		actualValue, err := tagTime("")
+
+		assert.NoError(t, err)
+		assert.Equal(t, TimeTag{Epoch: UnixEpoch(), Resolution: time.Second, Size: 32}, actualValue)
+	})
+
+	t.Run("verifies that keywords are parsed in any order", func(t *testing.T) {
+		actualValue, err := tagTime(`bctime:"16,cs,zcl,invalid"`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, TimeTag{Present: true, Epoch: ZigbeeEpoch(), Resolution: 10 * time.Millisecond, Size: 16, Invalid: true}, actualValue)
+	})
+
+	t.Run("verifies that unknown keywords error", func(t *testing.T) {
+		_, err := tagTime(`bctime:"zcl,minutes"`)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("verifies that widths larger than 64 bits error", func(t *testing.T) {
+		_, err := tagTime(`bctime:"zcl,72"`)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestTagsScale(t *testing.T) {
+	t.Run("verifies that wire type and parameters are parsed", func(t *testing.T) {
+		actualValue, err := tagScale(`bcscale:"int24,mul=2,div=100,offset=-40.5,round=floor"`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, ScaleTag{Signed: true, Size: 24, Multiplier: 2, Divisor: 100, Offset: -40.5, Rounding: RoundFloor}, actualValue)
+	})
+
+	t.Run("verifies that defaults are applied", func(t *testing.T) {
+		actualValue, err := tagScale(`bcscale:"uint8"`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, ScaleTag{Signed: false, Size: 8, Multiplier: 1, Divisor: 1}, actualValue)
+	})
+
+	t.Run("verifies that invalid wire types error", func(t *testing.T) {
+		_, err := tagScale(`bcscale:"float16"`)
+		assert.Error(t, err)
+
+		_, err = tagScale(`bcscale:"uint128"`)
+		assert.Error(t, err)
+	})
+
+	t.Run("verifies that invalid parameters error", func(t *testing.T) {
+		_, err := tagScale(`bcscale:"uint8,div=SPOON"`)
+		assert.Error(t, err)
+
+		_, err = tagScale(`bcscale:"uint8,scale=2"`)
+		assert.Error(t, err)
+
+		_, err = tagScale(`bcscale:"uint8,100"`)
+		assert.Error(t, err)
+
+		_, err = tagScale(`bcscale:"uint8,round=up"`)
+		assert.Error(t, err)
+
+		_, err = tagScale(`bcscale:"uint8,div=0"`)
+		assert.Error(t, err)
+	})
+}
+
+func TestTagsBitmap(t *testing.T) {
+	t.Run("verifies that size and endianness are parsed", func(t *testing.T) {
+		actualValue, err := tagBitmap(`bcbitmap:"32" bcendian:"big"`, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, BitmapTag{Size: 32, Endian: bitbuffer.BigEndian}, actualValue)
+	})
+
+	t.Run("verifies that missing or invalid sizes error", func(t *testing.T) {
+		_, err := tagBitmap(``, false)
+		assert.Error(t, err)
+
+		_, err = tagBitmap(`bcbitmap:"12"`, false)
+		assert.Error(t, err)
+
+		_, err = tagBitmap(`bcbitmap:"72"`, false)
+		assert.Error(t, err)
+	})
+}
+
+func TestTagsBit(t *testing.T) {
+	t.Run("verifies that positions and reserved are parsed", func(t *testing.T) {
+		actualValue, err := tagBit(`bcbit:"12"`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, BitTag{Present: true, Position: 12}, actualValue)
+
+		actualValue, err = tagBit(`bcbit:"reserved"`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, BitTag{Present: true, Reserved: true}, actualValue)
+	})
+
+	t.Run("verifies that invalid positions error", func(t *testing.T) {
+		_, err := tagBit(`bcbit:"64"`)
+		assert.Error(t, err)
+
+		_, err = tagBit(`bcbit:"first"`)
+		assert.Error(t, err)
+	})
+}
+
+func TestTagsBCD(t *testing.T) {
+	t.Run("verifies that digits and nibble order are parsed", func(t *testing.T) {
+		actualValue, err := tagBCD(`bcbcd:"12,low"`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, BCDTag{Digits: 12, Order: bitbuffer.LowNibbleFirst}, actualValue)
+
+		actualValue, err = tagBCD(`bcbcd:"4"`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, BCDTag{Digits: 4, Order: bitbuffer.HighNibbleFirst}, actualValue)
+	})
+
+	t.Run("verifies that invalid digits and keywords error", func(t *testing.T) {
+		_, err := tagBCD(`bcbcd:"0"`)
+		assert.Error(t, err)
+
+		_, err = tagBCD(`bcbcd:"SPOON"`)
+		assert.Error(t, err)
+
+		_, err = tagBCD(`bcbcd:"4,middle"`)
+		assert.Error(t, err)
+	})
+}
+
+func TestTagsChecksum(t *testing.T) {
+	t.Run("verifies that algorithm and start field are parsed", func(t *testing.T) {
+		actualValue, err := tagChecksum(`bcchecksum:"crc16ccitt,Header"`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, ChecksumTag{Present: true, Algorithm: "crc16ccitt", StartField: "Header"}, actualValue)
+	})
+
+	t.Run("verifies that missing start field errors", func(t *testing.T) {
+		_, err := tagChecksum(`bcchecksum:"crc16ccitt"`)
+		assert.Error(t, err)
+
+		_, err = tagChecksum(`bcchecksum:",Header"`)
+		assert.Error(t, err)
+	})
+}
+
+func TestTagsLength(t *testing.T) {
+	t.Run("verifies that field and count are parsed", func(t *testing.T) {
+		actualValue, err := tagLength(`bclength:"Payload"`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, LengthTag{Present: true, Field: "Payload"}, actualValue)
+
+		actualValue, err = tagLength(`bclength:"Payload,count"`)
+
+		assert.NoError(t, err)
+		assert.Equal(t, LengthTag{Present: true, Field: "Payload", Count: true}, actualValue)
+	})
+
+	t.Run("verifies that missing field or unknown keywords error", func(t *testing.T) {
+		_, err := tagLength(`bclength:""`)
+		assert.Error(t, err)
 
+		_, err = tagLength(`bclength:"Payload,bits"`)
 		assert.Error(t, err)
 	})
 }
@@ -0,0 +1,111 @@
+package bytecodec
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/shimmeringbee/bytecodec/bitbuffer"
+)
+
+var (
+	unixEpoch   = time.Unix(0, 0).UTC()
+	zigbeeEpoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// UnixEpoch returns the start of the unix bctime epoch, 1970-01-01 UTC.
+func UnixEpoch() time.Time {
+	return unixEpoch
+}
+
+// ZigbeeEpoch returns the start of the zcl bctime epoch, 2000-01-01 UTC.
+func ZigbeeEpoch() time.Time {
+	return zigbeeEpoch
+}
+
+func isTimeType(t reflect.Type) bool {
+	return t == timeType || t == durationType
+}
+
+func allOnes(bitSize int) uint64 {
+	if bitSize >= 64 {
+		return ^uint64(0)
+	}
+
+	return (1 << uint(bitSize)) - 1
+}
+
+func marshalTime(bb *bitbuffer.BitBuffer, name string, value reflect.Value, endian bitbuffer.Endian, tags reflect.StructTag) error {
+	timeTag, err := tagTime(tags)
+	if err != nil {
+		return err
+	}
+
+	if !timeTag.Present {
+		return fmt.Errorf("%w: field '%s' of type '%v' requires a %s tag", ErrUnsupportedType, name, value.Type(), TagTime)
+	}
+
+	var elapsed time.Duration
+
+	if value.Type() == timeType {
+		t := value.Interface().(time.Time)
+
+		if t.IsZero() && timeTag.Invalid {
+			return bb.WriteUint(allOnes(int(timeTag.Size)), endian, int(timeTag.Size))
+		}
+
+		if t.Before(timeTag.Epoch) {
+			return fmt.Errorf("cannot marshal time %v of field '%s', it is before the epoch %v", t, name, timeTag.Epoch)
+		}
+
+		elapsed = t.Sub(timeTag.Epoch)
+	} else {
+		elapsed = time.Duration(value.Int())
+
+		if elapsed < 0 {
+			return fmt.Errorf("cannot marshal negative duration %v of field '%s'", elapsed, name)
+		}
+	}
+
+	ticks := uint64(elapsed / timeTag.Resolution)
+
+	if timeTag.Invalid && ticks == allOnes(int(timeTag.Size)) {
+		return fmt.Errorf("cannot marshal %v of field '%s', it encodes as the invalid value", elapsed, name)
+	}
+
+	return bb.WriteUint(ticks, endian, int(timeTag.Size))
+}
+
+func unmarshalTime(bb *bitbuffer.BitBuffer, name string, value reflect.Value, endian bitbuffer.Endian, tags reflect.StructTag) error {
+	timeTag, err := tagTime(tags)
+	if err != nil {
+		return err
+	}
+
+	if !timeTag.Present {
+		return fmt.Errorf("%w: field '%s' of type '%v' requires a %s tag", ErrUnsupportedType, name, value.Type(), TagTime)
+	}
+
+	ticks, err := bb.ReadUint(endian, int(timeTag.Size))
+	if err != nil {
+		return err
+	}
+
+	if timeTag.Invalid && ticks == allOnes(int(timeTag.Size)) {
+		value.Set(reflect.Zero(value.Type()))
+		return nil
+	}
+
+	elapsed := time.Duration(ticks) * timeTag.Resolution
+
+	if value.Type() == timeType {
+		value.Set(reflect.ValueOf(timeTag.Epoch.Add(elapsed)))
+	} else {
+		value.SetInt(int64(elapsed))
+	}
+
+	return nil
+}
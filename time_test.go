@@ -0,0 +1,117 @@
+package bytecodec
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTime(t *testing.T) {
+	t.Run("verify time is marshalled as seconds since the zigbee epoch", func(t *testing.T) {
+		type StructUnderTest struct {
+			One time.Time `bctime:"zcl,s,32"`
+		}
+
+		instance := &StructUnderTest{One: ZigbeeEpoch().Add(0x01020304 * time.Second)}
+		actualBytes, err := Marshal(instance)
+
+		expectedBytes := []byte{0x04, 0x03, 0x02, 0x01}
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedBytes, actualBytes)
+	})
+
+	t.Run("verify time is unmarshalled as milliseconds since the unix epoch, big endian", func(t *testing.T) {
+		type StructUnderTest struct {
+			One time.Time `bctime:"unix,ms,48" bcendian:"big"`
+		}
+
+		expectedStruct := &StructUnderTest{One: time.Unix(0, 0x010203040506*int64(time.Millisecond)).UTC()}
+
+		actualStruct := &StructUnderTest{}
+		err := Unmarshal([]byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06}, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedStruct, actualStruct)
+	})
+
+	t.Run("verify zero time round trips as the invalid value", func(t *testing.T) {
+		type StructUnderTest struct {
+			One time.Time `bctime:"zcl,invalid"`
+		}
+
+		instance := &StructUnderTest{}
+		actualBytes, err := Marshal(instance)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xff, 0xff, 0xff, 0xff}, actualBytes)
+
+		actualStruct := &StructUnderTest{One: time.Now()}
+		err = Unmarshal(actualBytes, actualStruct)
+
+		assert.NoError(t, err)
+		assert.True(t, actualStruct.One.IsZero())
+	})
+
+	t.Run("verify time before the epoch errors", func(t *testing.T) {
+		type StructUnderTest struct {
+			One time.Time `bctime:"zcl"`
+		}
+
+		instance := &StructUnderTest{One: time.Date(1999, time.December, 31, 0, 0, 0, 0, time.UTC)}
+		_, err := Marshal(instance)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("verify time which does not fit the width errors", func(t *testing.T) {
+		type StructUnderTest struct {
+			One time.Time `bctime:"zcl,8"`
+		}
+
+		instance := &StructUnderTest{One: ZigbeeEpoch().Add(time.Hour)}
+		_, err := Marshal(instance)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("verify durations are marshalled in hundredths of a second", func(t *testing.T) {
+		type StructUnderTest struct {
+			One time.Duration `bctime:"cs,16"`
+		}
+
+		instance := &StructUnderTest{One: 2*time.Second + 500*time.Millisecond}
+		actualBytes, err := Marshal(instance)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xfa, 0x00}, actualBytes)
+
+		actualStruct := &StructUnderTest{}
+		err = Unmarshal(actualBytes, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, instance, actualStruct)
+	})
+
+	t.Run("verify negative durations error", func(t *testing.T) {
+		type StructUnderTest struct {
+			One time.Duration `bctime:"s"`
+		}
+
+		_, err := Marshal(&StructUnderTest{One: -time.Second})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("verify time without a time tag errors", func(t *testing.T) {
+		type StructUnderTest struct {
+			One time.Time
+		}
+
+		_, err := Marshal(&StructUnderTest{})
+
+		assert.True(t, errors.Is(err, ErrUnsupportedType))
+	})
+}
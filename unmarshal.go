@@ -10,77 +10,164 @@ import (
 	"github.com/shimmeringbee/bytecodec/bitbuffer"
 )
 
-func Unmarshal(data []byte, v interface{}) (err error) {
-	bb := bitbuffer.NewBitBufferFromBytes(data)
-	return UnmarshalFromBitBuffer(bb, v)
+// Unmarshal reads data into the value pointed to by v. Slices already held by v are unmarshalled into their existing
+// backing arrays if they have the capacity, so any other slice sharing a backing array with them, such as one kept from
+// an earlier Unmarshal into the same value, is overwritten. Copy slices which must outlive the next Unmarshal.
+func Unmarshal(data []byte, v interface{}, opts ...Option) (err error) {
+	o := newOptions(opts)
+	defer releaseOptions(o)
+
+	return unmarshalBytes(data, v, o)
 }
 
-func UnmarshalFromBitBuffer(bb *bitbuffer.BitBuffer, v interface{}) (err error) {
+func unmarshalBytes(data []byte, v interface{}, o *options) error {
+	bb := bitBufferPool.Get().(*bitbuffer.BitBuffer)
+	defer bitBufferPool.Put(bb)
+
+	bb.ResetBytes(data)
+	defer bb.ResetBytes(nil)
+
+	return unmarshalRoot(bb, v, o)
+}
+
+func UnmarshalFromBitBuffer(bb *bitbuffer.BitBuffer, v interface{}, opts ...Option) (err error) {
+	o := newOptions(opts)
+	defer releaseOptions(o)
+
+	return unmarshalRoot(bb, v, o)
+}
+
+func unmarshalRoot(bb *bitbuffer.BitBuffer, v interface{}, o *options) error {
+	var t *tracer
+
+	if o.trace != nil {
+		t = &tracer{fn: o.trace}
+	}
+
+	return unmarshalTraced(bb, v, o, t, 0)
+}
+
+func unmarshalTraced(bb *bitbuffer.BitBuffer, v interface{}, o *options, t *tracer, bitOffset int) error {
 	val := reflect.Indirect(reflect.ValueOf(v))
 
 	if !val.CanSet() {
 		return fmt.Errorf("cannot unmarshall to non pointer")
 	}
 
+	ancestors := acquireAncestors()
+	defer releaseAncestors(ancestors)
+
 	ctx := Context{
 		Root:         val,
 		CurrentIndex: 0,
+		options:      o,
+		ancestors:    *ancestors,
+		trace:        t,
+		bitOffset:    bitOffset,
 	}
 
-	if err = unmarshalValue(bb, ctx, "root", val, val, val, ""); err != nil {
-		return
+	ctx.trace.value(bb, ctx, val)
+	err := unmarshalValue(bb, ctx, "root", val, val, val, "")
+	ctx.trace.end(bb, ctx, val, err)
+
+	return err
+}
+
+// TryUnmarshal unmarshals from the BitBuffer, restoring the buffer to its original position if unmarshalling fails so
+// that another type can be attempted. The value may be partially populated on failure.
+func TryUnmarshal(bb *bitbuffer.BitBuffer, v interface{}, opts ...Option) error {
+	mark := bb.Mark()
+
+	if err := UnmarshalFromBitBuffer(bb, v, opts...); err != nil {
+		mark.Reset()
+		return err
 	}
 
-	return
+	return nil
 }
 
 func unmarshalValue(bb *bitbuffer.BitBuffer, ctx Context, name string, value reflect.Value, root reflect.Value, parent reflect.Value, tags reflect.StructTag) (err error) {
 	kind := value.Kind()
 
-	endian := tagEndianness(tags)
+	if skip, err := shouldIgnore(ctx, tags, root, parent); skip || err != nil {
+		if skip {
+			clearSkipped(ctx, value)
+			ctx.trace.skip(ctx, tags, root, parent)
+		}
 
-	if skip, err := shouldIgnore(tags, root, parent); skip || err != nil {
 		return err
 	}
 
-	fieldWidth, err := tagFieldWidth(tags)
+	fieldWidth, err := tagFieldWidth(tags, ctx.options.lenientTags)
+
+	if err != nil {
+		return
+	}
+
+	endian, err := tagEndianness(tags, ctx.options.lenientTags)
 
 	if err != nil {
 		return
 	}
 
+	// Values within a struct use the Unmarshaler of their pointer, the root value does not so that an Unmarshaler can
+	// delegate to UnmarshalFromBitBuffer with itself.
+	if kind != reflect.Ptr && len(ctx.ancestors) > 0 && value.CanAddr() && value.Addr().Type().Implements(unmarshalerType) {
+		return unmarshalPtr(bb, ctx, name, value.Addr(), root, parent, tags)
+	}
+
+	if _, tagPresent := tags.Lookup(TagScale); tagPresent {
+		return unmarshalScaled(bb, name, value, endian, tags)
+	}
+
+	if _, tagPresent := tags.Lookup(TagBCD); tagPresent {
+		return unmarshalBCD(bb, name, value, tags)
+	}
+
+	if isTimeType(value.Type()) {
+		return unmarshalTime(bb, name, value, endian, tags)
+	}
+
+	if fieldWidth.Varint && !varintKind(kind) {
+		return fmt.Errorf("%w: %s varint can not be used on field '%s' of type '%v'", ErrUnsupportedType, TagFieldWidth, name, kind)
+	}
+
 	switch kind {
 	case reflect.Bool:
 		err = unmarshalBool(bb, endian, fieldWidth.Width(8), value)
 	case reflect.Uint8:
-		err = unmarshalUint(bb, endian, fieldWidth.Width(8), value)
+		err = unmarshalUint(bb, name, endian, fieldWidth, 8, value)
 	case reflect.Uint16:
-		err = unmarshalUint(bb, endian, fieldWidth.Width(16), value)
+		err = unmarshalUint(bb, name, endian, fieldWidth, 16, value)
 	case reflect.Uint32:
-		err = unmarshalUint(bb, endian, fieldWidth.Width(32), value)
+		err = unmarshalUint(bb, name, endian, fieldWidth, 32, value)
 	case reflect.Uint64:
-		err = unmarshalUint(bb, endian, fieldWidth.Width(64), value)
+		err = unmarshalUint(bb, name, endian, fieldWidth, 64, value)
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		err = unmarshalInt(bb, name, fieldWidth, value)
 	case reflect.Struct:
-		err = unmarshalStruct(bb, value, root)
+		err = unmarshalStruct(bb, ctx, value, root)
 	case reflect.Array:
 		err = unmarshalArray(bb, ctx, value, root, parent, tags)
 	case reflect.Slice:
 		err = unmarshalSlice(bb, ctx, value, root, parent, tags)
 	case reflect.Ptr:
-		err = unmarshalPtr(bb, ctx, value)
+		err = unmarshalPtr(bb, ctx, name, value, root, parent, tags)
 	case reflect.String:
-		err = unmarshalString(bb, value, tags)
+		err = unmarshalString(bb, ctx, value, tags)
 	default:
 		err = fmt.Errorf("%w: field '%s' of type '%v'", ErrUnsupportedType, name, kind)
 	}
 
+	if err == nil && ctx.options.strictEnums {
+		err = checkEnum(name, value)
+	}
+
 	return
 }
 
-func unmarshalPtr(bb *bitbuffer.BitBuffer, ctx Context, value reflect.Value) error {
-	unmarshaler := reflect.TypeOf((*Unmarshaler)(nil)).Elem()
-
-	if value.Type().Implements(unmarshaler) {
+func unmarshalPtr(bb *bitbuffer.BitBuffer, ctx Context, name string, value reflect.Value, root reflect.Value, parent reflect.Value, tags reflect.StructTag) error {
+	if value.Type().Implements(unmarshalerType) {
 		if value.IsNil() {
 			e := reflect.New(value.Type().Elem())
 			if value.CanSet() {
@@ -97,26 +184,131 @@ func unmarshalPtr(bb *bitbuffer.BitBuffer, ctx Context, value reflect.Value) err
 		return retVals[0].Interface().(error)
 	}
 
-	return fmt.Errorf("%w: field does not support the Marshaler interface", ErrUnsupportedType)
+	if stringTag, nullable := nullableString(value.Type(), tags, ctx.options.lenientTags); nullable {
+		str, err := bb.ReadStringLengthPrefixedNullable(stringTag.Endian, int(stringTag.Size))
+		if err != nil {
+			return err
+		}
+
+		if str == nil {
+			value.Set(reflect.Zero(value.Type()))
+			return nil
+		}
+
+		value.Set(reflect.New(value.Type().Elem()))
+		value.Elem().SetString(*str)
+
+		return nil
+	}
+
+	if value.IsNil() {
+		value.Set(reflect.New(value.Type().Elem()))
+	}
+
+	return unmarshalValue(bb, ctx, name, value.Elem(), root, parent, tags)
 }
 
-func unmarshalStruct(bb *bitbuffer.BitBuffer, structValue reflect.Value, root reflect.Value) error {
+func unmarshalStruct(bb *bitbuffer.BitBuffer, ctx Context, structValue reflect.Value, root reflect.Value) error {
+	ctx.Root = structValue
+	ctx.CurrentIndex = 0
+	ctx.ancestors = append(ctx.ancestors, structValue)
+
+	plan := cachedStructPlan(structValue.Type(), ctx.defaults, ctx.options.lenientTags)
+	if plan.bitmapErr != nil {
+		return plan.bitmapErr
+	}
+
+	if plan.isBitmap {
+		start := bb.Position()
+
+		if err := unmarshalBitmap(bb, plan.bitmap, structValue); err != nil {
+			return err
+		}
+
+		ctx.trace.bitmap(bb, ctx, start, plan.bitmap, structValue)
+		return nil
+	}
+
+	return unmarshalFields(bb, ctx, structValue, root, structValue)
+}
+
+func unmarshalFields(bb *bitbuffer.BitBuffer, ctx Context, structValue reflect.Value, root reflect.Value, parent reflect.Value) (err error) {
 	structType := structValue.Type()
 
-	ctx := Context{
-		Root:         structValue,
-		CurrentIndex: 0,
+	plan := cachedStructPlan(structType, ctx.defaults, ctx.options.lenientTags)
+	if plan.fieldsErr != nil {
+		return plan.fieldsErr
 	}
 
-	for i := 0; i < structValue.NumField(); i++ {
+	ctx.defaults = plan.defaults
+
+	var spans map[int]*checksumSpan
+	var starts map[int][]*checksumSpan
+
+	if plan.checksums {
+		if spans, starts, err = findChecksums(structType); err != nil {
+			return err
+		}
+
+		defer stopChecksums(spans)
+	}
+
+	var targets map[int]*lengthSpan
+
+	if plan.lengths {
+		if _, targets, err = findLengths(structType); err != nil {
+			return err
+		}
+	}
+
+	for i, field := range plan.fields {
 		value := structValue.Field(i)
-		field := structType.Field(i)
-		tags := field.Tag
-		name := field.Name
+		tags := field.tags
+		name := field.name
 
 		ctx.CurrentIndex = i
 
-		if err := unmarshalValue(bb, ctx, name, value, root, structValue, tags); err != nil {
+		if err := startChecksums(bb, starts[i]); err != nil {
+			return err
+		}
+
+		if field.skip {
+			continue
+		}
+
+		checksum, target := spans[i], targets[i]
+		flattened := field.flattened
+
+		ctx.trace.field(bb, ctx, name, value)
+
+		if flattened || checksum != nil || target != nil {
+			if skip, err := shouldIgnore(ctx, tags, root, parent); skip || err != nil {
+				if err != nil {
+					ctx.trace.end(bb, ctx, value, err)
+					return err
+				}
+
+				clearSkipped(ctx, value)
+				ctx.trace.skip(ctx, tags, root, parent)
+				ctx.trace.end(bb, ctx, value, nil)
+				continue
+			}
+		}
+
+		switch {
+		case flattened:
+			err = unmarshalFields(bb, ctx, value, root, parent)
+		case checksum != nil:
+			err = unmarshalChecksum(bb, name, value, checksum, tags, ctx.options.lenientTags)
+		case target != nil:
+			err = unmarshalLengthTarget(bb, ctx, name, value, root, parent, tags, structValue.Field(target.lengthIndex).Uint(), target.tag.Count)
+		default:
+			err = unmarshalValue(bb, ctx, name, value, root, parent, tags)
+		}
+
+		ctx.trace.end(bb, ctx, value, err)
+
+		if err != nil {
 			return err
 		}
 	}
@@ -124,6 +316,14 @@ func unmarshalStruct(bb *bitbuffer.BitBuffer, structValue reflect.Value, root re
 	return nil
 }
 
+// clearSkipped zeroes a field excluded by its bcincludeif condition when unmarshalling with the Overwrite option, so
+// that it does not retain a value from before unmarshalling.
+func clearSkipped(ctx Context, value reflect.Value) {
+	if ctx.options.overwrite && value.CanSet() {
+		value.Set(reflect.Zero(value.Type()))
+	}
+}
+
 func unmarshalBool(bb *bitbuffer.BitBuffer, endian bitbuffer.Endian, bitSize int, value reflect.Value) error {
 	readValue, err := bb.ReadUint(endian, bitSize)
 
@@ -136,26 +336,73 @@ func unmarshalBool(bb *bitbuffer.BitBuffer, endian bitbuffer.Endian, bitSize int
 	return nil
 }
 
-func unmarshalUint(bb *bitbuffer.BitBuffer, endian bitbuffer.Endian, bitSize int, value reflect.Value) error {
-	readValue, err := bb.ReadUint(endian, bitSize)
+func unmarshalUint(bb *bitbuffer.BitBuffer, name string, endian bitbuffer.Endian, fieldWidth FieldWidthTag, defaultWidth int, value reflect.Value) error {
+	var readValue uint64
+	var err error
+
+	if fieldWidth.Varint {
+		readValue, err = bb.ReadUvarint()
+	} else {
+		readValue, err = bb.ReadUint(endian, fieldWidth.Width(defaultWidth))
+	}
 
 	if err != nil {
 		return err
 	}
 
+	if fieldWidth.Varint && value.OverflowUint(readValue) {
+		return fmt.Errorf("%w: field '%s' value %d overflows '%v'", bitbuffer.ErrorVarintOverflow, name, readValue, value.Type())
+	}
+
 	value.SetUint(readValue)
 	return nil
 }
 
+func unmarshalInt(bb *bitbuffer.BitBuffer, name string, fieldWidth FieldWidthTag, value reflect.Value) error {
+	if !fieldWidth.Varint {
+		return fmt.Errorf("%w: signed field '%s' must be a varint", ErrUnsupportedType, name)
+	}
+
+	readValue, err := bb.ReadVarint()
+	if err != nil {
+		return err
+	}
+
+	if value.OverflowInt(readValue) {
+		return fmt.Errorf("%w: field '%s' value %d overflows '%v'", bitbuffer.ErrorVarintOverflow, name, readValue, value.Type())
+	}
+
+	value.SetInt(readValue)
+	return nil
+}
+
 func unmarshalArray(bb *bitbuffer.BitBuffer, ctx Context, value reflect.Value, root reflect.Value, parent reflect.Value, tags reflect.StructTag) error {
-	arraySize, err := readArraySliceLength(bb, tags, value.Len())
+	arraySize, _, err := readArraySliceLength(bb, tags, value.Len(), ctx.options.lenientTags)
 	if err != nil {
 		return err
 	}
 
+	return unmarshalArrayElements(bb, ctx, value, root, parent, tags, arraySize)
+}
+
+func unmarshalArrayElements(bb *bitbuffer.BitBuffer, ctx Context, value reflect.Value, root reflect.Value, parent reflect.Value, tags reflect.StructTag, arraySize int) error {
+	if arraySize > value.Len() {
+		return fmt.Errorf("array length %d is longer than the %d elements of %v", arraySize, value.Len(), value.Type())
+	}
+
+	if isBulkBytes(ctx, value.Type(), tags) {
+		return bb.ReadFull(value.Slice(0, arraySize).Bytes())
+	}
+
 	for i := 0; i < arraySize; i++ {
-		name := fmt.Sprintf("array[%d]", i)
-		if err := unmarshalValue(bb, ctx, name, value.Index(i), root, parent, tags); err != nil {
+		name := arrayElementNames.name(i)
+		element := value.Index(i)
+
+		ctx.trace.element(bb, ctx, i, element)
+		err := unmarshalValue(bb, ctx, name, element, root, parent, tags)
+		ctx.trace.end(bb, ctx, element, err)
+
+		if err != nil {
 			return err
 		}
 	}
@@ -164,55 +411,156 @@ func unmarshalArray(bb *bitbuffer.BitBuffer, ctx Context, value reflect.Value, r
 }
 
 func unmarshalSlice(bb *bitbuffer.BitBuffer, ctx Context, value reflect.Value, root reflect.Value, parent reflect.Value, tags reflect.StructTag) error {
-	sliceSize, err := readArraySliceLength(bb, tags, math.MaxInt32)
+	sliceSize, invalid, err := readArraySliceLength(bb, tags, unboundedLength, ctx.options.lenientTags)
 	if err != nil {
 		return err
 	}
 
-	value.Set(reflect.MakeSlice(value.Type(), 0, 0))
+	if invalid {
+		value.Set(reflect.Zero(value.Type()))
+		return nil
+	}
+
+	return unmarshalSliceElements(bb, ctx, value, root, parent, tags, sliceSize)
+}
+
+func unmarshalSliceElements(bb *bitbuffer.BitBuffer, ctx Context, value reflect.Value, root reflect.Value, parent reflect.Value, tags reflect.StructTag, sliceSize int) error {
+	if isBulkBytes(ctx, value.Type(), tags) {
+		return unmarshalSliceBytes(bb, ctx, value, sliceSize)
+	}
+
+	elemType := value.Type().Elem()
+
+	if value.IsNil() || (sliceSize != unboundedLength && value.Cap() < sliceSize) {
+		capacity := 0
+
+		if sliceSize != unboundedLength {
+			capacity = sliceSize
+
+			if remaining := bb.Remaining(); capacity > remaining {
+				capacity = remaining
+			}
+		}
+
+		value.Set(reflect.MakeSlice(value.Type(), 0, capacity))
+	}
+
+	value.SetLen(0)
 
 	for i := 0; i < sliceSize; i++ {
-		sliceValue := reflect.New(value.Type().Elem()).Elem()
+		if i < value.Cap() {
+			value.SetLen(i + 1)
+
+			if !ctx.options.overwrite {
+				value.Index(i).Set(reflect.Zero(elemType))
+			}
+		} else {
+			value.Set(reflect.Append(value, reflect.Zero(elemType)))
+		}
+
+		name := sliceElementNames.name(i)
+		element := value.Index(i)
 
-		name := fmt.Sprintf("slice[%d]", i)
-		if err := unmarshalValue(bb, ctx, name, sliceValue, root, parent, tags); err != nil {
-			if errors.Is(err, io.EOF) {
+		ctx.trace.element(bb, ctx, i, element)
+
+		if err := unmarshalValue(bb, ctx, name, element, root, parent, tags); err != nil {
+			if errors.Is(err, io.EOF) && sliceSize == unboundedLength {
+				ctx.trace.discard()
+				value.SetLen(i)
 				return nil
 			}
 
+			ctx.trace.end(bb, ctx, element, err)
 			return err
 		}
 
-		value.Set(reflect.Append(value, sliceValue))
+		ctx.trace.end(bb, ctx, element, nil)
 	}
 
 	return nil
 }
 
-func readArraySliceLength(bb *bitbuffer.BitBuffer, tags reflect.StructTag, max int) (int, error) {
-	length, err := tagSlicePrefix(tags)
+// unmarshalSliceBytes reads a byte slice in bulk, into the existing slice if it has the capacity.
+func unmarshalSliceBytes(bb *bitbuffer.BitBuffer, ctx Context, value reflect.Value, sliceSize int) error {
+	if sliceSize == unboundedLength {
+		sliceSize = bb.Remaining() / 8
+	}
+
+	if ctx.options.zeroCopy {
+		data, err := bb.ReadBytesShared(sliceSize)
+		if err != nil {
+			return err
+		}
+
+		value.SetBytes(data)
+		return nil
+	}
+
+	if !value.IsNil() && value.Cap() >= sliceSize {
+		value.SetLen(sliceSize)
+		return bb.ReadFull(value.Bytes())
+	}
+
+	data, err := bb.ReadBytes(sliceSize)
 	if err != nil {
-		return 0, err
+		return err
+	}
+
+	value.SetBytes(data)
+	return nil
+}
+
+const unboundedLength = math.MaxInt32
+
+func readArraySliceLength(bb *bitbuffer.BitBuffer, tags reflect.StructTag, max int, lenient bool) (int, bool, error) {
+	length, err := tagSlicePrefix(tags, lenient)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if length.Varint {
+		readSize, err := bb.ReadUvarint()
+		if err != nil {
+			return 0, false, err
+		}
+
+		if readSize >= unboundedLength {
+			return 0, false, fmt.Errorf("%w: slice length %d is too large", bitbuffer.ErrorVarintOverflow, readSize)
+		}
+
+		return int(readSize), false, nil
 	}
 
 	if length.HasPrefix() {
 		readSize, err := bb.ReadUint(length.Endian, int(length.Size))
 		if err != nil {
-			return 0, err
+			return 0, false, err
 		}
 
-		return int(readSize), nil
+		if length.Invalid && readSize == bitbuffer.InvalidLengthPrefix(int(length.Size)) {
+			return 0, true, nil
+		}
+
+		if readSize >= unboundedLength {
+			return 0, false, fmt.Errorf("%w: slice length %d is longer than the data", io.ErrUnexpectedEOF, readSize)
+		}
+
+		return int(readSize), false, nil
 	}
 
-	return max, nil
+	return max, false, nil
 }
 
-func unmarshalString(bb *bitbuffer.BitBuffer, value reflect.Value, tags reflect.StructTag) error {
-	stringTag, err := tagStringType(tags)
+func unmarshalString(bb *bitbuffer.BitBuffer, ctx Context, value reflect.Value, tags reflect.StructTag) error {
+	stringTag, err := tagStringType(tags, ctx.options.lenientTags)
 	if err != nil {
 		return err
 	}
 
+	if ctx.options.interner != nil {
+		return unmarshalInternedString(bb, ctx.options.interner, value, stringTag)
+	}
+
 	if stringTag.Termination == Null {
 		str, err := bb.ReadStringNullTerminated(int(stringTag.Size))
 		if err != nil {
@@ -220,6 +568,24 @@ func unmarshalString(bb *bitbuffer.BitBuffer, value reflect.Value, tags reflect.
 		}
 
 		value.SetString(str)
+	} else if stringTag.Varint {
+		str, err := bb.ReadStringVarintPrefixed()
+		if err != nil {
+			return err
+		}
+
+		value.SetString(str)
+	} else if stringTag.Invalid {
+		str, err := bb.ReadStringLengthPrefixedNullable(stringTag.Endian, int(stringTag.Size))
+		if err != nil {
+			return err
+		}
+
+		if str != nil {
+			value.SetString(*str)
+		} else {
+			value.SetString("")
+		}
 	} else {
 		str, err := bb.ReadStringLengthPrefixed(stringTag.Endian, int(stringTag.Size))
 		if err != nil {
@@ -2,8 +2,11 @@ package bytecodec
 
 import (
 	"errors"
+	"io"
+	"reflect"
 	"testing"
 
+	"github.com/shimmeringbee/bytecodec/bitbuffer"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -309,6 +312,47 @@ func TestUnmarshall(t *testing.T) {
 		assert.Equal(t, expectedStruct, actualStruct)
 	})
 
+	t.Run("verify slices with length annotations error if truncated", func(t *testing.T) {
+		type StructUnderTest struct {
+			One []uint16 `bcsliceprefix:"8"`
+		}
+
+		actualStruct := &StructUnderTest{}
+		err := Unmarshal([]byte{0x02, 0x01, 0x00}, actualStruct)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("verify byte slices with lengths beyond the data error", func(t *testing.T) {
+		type StructUnderTest struct {
+			One []byte `bcsliceprefix:"32"`
+		}
+
+		for _, data := range [][]byte{{0xfe, 0xff, 0xff, 0x7f, 0x61}, {0xff, 0xff, 0xff, 0x7f, 0x61}, {0xff, 0xff, 0xff, 0xff, 0x61}} {
+			err := Unmarshal(data, &StructUnderTest{})
+			assert.True(t, errors.Is(err, io.ErrUnexpectedEOF), data)
+
+			err = Unmarshal(data, &StructUnderTest{One: make([]byte, 0, 8)})
+			assert.True(t, errors.Is(err, io.ErrUnexpectedEOF), data)
+		}
+	})
+
+	t.Run("verify strings with lengths beyond the data error", func(t *testing.T) {
+		type StructUnderTest struct {
+			Short string `bcstringtype:"prefix,32"`
+		}
+
+		type LongStructUnderTest struct {
+			Long string `bcstringtype:"prefix,64"`
+		}
+
+		err := Unmarshal([]byte{0xff, 0xff, 0xff, 0x7f, 0x61}, &StructUnderTest{})
+		assert.True(t, errors.Is(err, io.ErrUnexpectedEOF))
+
+		err = Unmarshal([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x61}, &LongStructUnderTest{})
+		assert.True(t, errors.Is(err, io.ErrUnexpectedEOF))
+	})
+
 	t.Run("verify slices support implicit length annotations, uint16, big endian", func(t *testing.T) {
 		type StructUnderTest struct {
 			One []byte `bcsliceprefix:"16,big"`
@@ -369,6 +413,38 @@ func TestUnmarshall(t *testing.T) {
 		assert.Equal(t, expectedStruct, actualStruct)
 	})
 
+	t.Run("verify string pointers with invalid keyword distinguish invalid from empty", func(t *testing.T) {
+		type StructUnderTest struct {
+			One   *string `bcstringtype:"prefix,8,invalid"`
+			Two   *string `bcstringtype:"prefix,8,invalid"`
+			Three string  `bcstringtype:"prefix,8,invalid"`
+		}
+
+		actualStruct := &StructUnderTest{}
+		err := Unmarshal([]byte{0xff, 0x00, 0xff}, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Nil(t, actualStruct.One)
+		assert.NotNil(t, actualStruct.Two)
+		assert.Equal(t, "", *actualStruct.Two)
+		assert.Equal(t, "", actualStruct.Three)
+	})
+
+	t.Run("verify slices with invalid keyword distinguish invalid from empty", func(t *testing.T) {
+		type StructUnderTest struct {
+			One []byte `bcsliceprefix:"8,invalid"`
+			Two []byte `bcsliceprefix:"8,invalid"`
+		}
+
+		actualStruct := &StructUnderTest{}
+		err := Unmarshal([]byte{0xff, 0x00}, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Nil(t, actualStruct.One)
+		assert.NotNil(t, actualStruct.Two)
+		assert.Len(t, actualStruct.Two, 0)
+	})
+
 	t.Run("verify null terminated string unmarshals", func(t *testing.T) {
 		type StructUnderTest struct {
 			One string `bcstringtype:"null"`
@@ -507,6 +583,253 @@ func TestUnmarshall(t *testing.T) {
 		assert.Equal(t, expectedStruct, actualStruct)
 	})
 
+	t.Run("verify varints on non integer fields error", func(t *testing.T) {
+		type BoolUnderTest struct {
+			One bool `bcfieldwidth:"varint"`
+		}
+
+		type StringUnderTest struct {
+			One string `bcfieldwidth:"varint"`
+		}
+
+		err := Unmarshal([]byte{0x01}, &BoolUnderTest{})
+		assert.True(t, errors.Is(err, ErrUnsupportedType))
+
+		err = Unmarshal([]byte{0x01, 0x61}, &StringUnderTest{})
+		assert.True(t, errors.Is(err, ErrUnsupportedType))
+	})
+
+	t.Run("verify signed integers which are not varints error", func(t *testing.T) {
+		type StructUnderTest struct {
+			One int16
+		}
+
+		err := Unmarshal([]byte{0xff, 0xff}, &StructUnderTest{})
+
+		assert.True(t, errors.Is(err, ErrUnsupportedType))
+	})
+
+	t.Run("verify varint integers, slice prefixes and string prefixes are unmarshalled", func(t *testing.T) {
+		type StructUnderTest struct {
+			Unsigned uint32  `bcfieldwidth:"varint"`
+			Signed   int16   `bcfieldwidth:"varint"`
+			Slice    []uint8 `bcsliceprefix:"varint"`
+			String   string  `bcstringtype:"prefix,varint"`
+		}
+
+		expectedStruct := &StructUnderTest{Unsigned: 300, Signed: -65, Slice: []uint8{0xaa}, String: "Hi"}
+
+		actualStruct := &StructUnderTest{}
+		err := Unmarshal([]byte{0xac, 0x02, 0x81, 0x01, 0x01, 0xaa, 0x02, 0x48, 0x69}, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedStruct, actualStruct)
+	})
+
+	t.Run("verify varints which overflow the field type error", func(t *testing.T) {
+		type StructUnderTest struct {
+			Unsigned uint8 `bcfieldwidth:"varint"`
+		}
+
+		err := Unmarshal([]byte{0xac, 0x02}, &StructUnderTest{})
+
+		assert.True(t, errors.Is(err, bitbuffer.ErrorVarintOverflow))
+	})
+
+	t.Run("verify over long varints error", func(t *testing.T) {
+		type StructUnderTest struct {
+			Slice []uint8 `bcsliceprefix:"varint"`
+		}
+
+		err := Unmarshal([]byte{0x80, 0x00}, &StructUnderTest{})
+
+		assert.True(t, errors.Is(err, bitbuffer.ErrorVarintOverlong))
+	})
+
+	t.Run("verify a truncated trailing varint of an unbounded slice errors rather than being dropped", func(t *testing.T) {
+		type StructUnderTest struct {
+			Values []uint16 `bcfieldwidth:"varint"`
+		}
+
+		actualStruct := &StructUnderTest{}
+		err := Unmarshal([]byte{0x01, 0xac, 0x02, 0xac}, actualStruct)
+
+		assert.True(t, errors.Is(err, io.ErrUnexpectedEOF))
+
+		err = Unmarshal([]byte{0x01, 0xac, 0x02}, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uint16{1, 300}, actualStruct.Values)
+	})
+
+	t.Run("verify byte slices and arrays are unmarshalled in bulk, including when not aligned", func(t *testing.T) {
+		type NamedBytes []uint8
+
+		type StructUnderTest struct {
+			Flags  uint8 `bcfieldwidth:"4"`
+			Array  [2]byte
+			Slice  NamedBytes `bcsliceprefix:"8"`
+			Padded uint8      `bcfieldwidth:"4"`
+			Rest   []byte
+		}
+
+		expectedStruct := &StructUnderTest{Flags: 0x0a, Array: [2]byte{0x12, 0x34}, Slice: NamedBytes{0x56}, Padded: 0x07, Rest: []byte{0x89}}
+
+		actualStruct := &StructUnderTest{}
+		err := Unmarshal([]byte{0xa1, 0x23, 0x40, 0x15, 0x67, 0x89}, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedStruct, actualStruct)
+	})
+
+	t.Run("verify pointers to values are allocated and unmarshalled", func(t *testing.T) {
+		type StructUnderTest struct {
+			HasOne bool
+			One    *uint16 `bcincludeif:"HasOne" bcendian:"big"`
+		}
+
+		one := uint16(0x8001)
+		expectedStruct := &StructUnderTest{HasOne: true, One: &one}
+
+		actualStruct := &StructUnderTest{}
+		err := Unmarshal([]byte{0x01, 0x80, 0x01}, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedStruct, actualStruct)
+	})
+
+	t.Run("verify pointers excluded by includeIf are left nil", func(t *testing.T) {
+		type StructUnderTest struct {
+			HasOne bool
+			One    *uint16 `bcincludeif:"HasOne"`
+		}
+
+		actualStruct := &StructUnderTest{}
+		err := Unmarshal([]byte{0x00}, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Nil(t, actualStruct.One)
+	})
+
+	t.Run("verify includeIf supports named types", func(t *testing.T) {
+		type Mode uint8
+
+		type StructUnderTest struct {
+			Mode Mode
+			One  uint8 `bcincludeif:"Mode==2"`
+		}
+
+		expectedStruct := &StructUnderTest{Mode: 2, One: 1}
+
+		actualStruct := &StructUnderTest{}
+		err := Unmarshal([]byte{0x02, 0x01}, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedStruct, actualStruct)
+	})
+
+	t.Run("verify embedded structs are flattened for includeIf references", func(t *testing.T) {
+		type Header struct {
+			HasTwo bool
+		}
+
+		type StructUnderTest struct {
+			Header
+			Two uint8 `bcincludeif:".HasTwo"`
+		}
+
+		expectedStruct := &StructUnderTest{Header: Header{HasTwo: true}, Two: 2}
+
+		actualStruct := &StructUnderTest{}
+		err := Unmarshal([]byte{0x01, 0x02}, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedStruct, actualStruct)
+	})
+
+	t.Run("verify includeIf in slice elements refers to ancestors and earlier elements", func(t *testing.T) {
+		type Element struct {
+			Kind  uint8
+			Value uint8 `bcincludeif:"..Header.Flags==1"`
+			Extra uint8 `bcincludeif:"..Elements[0].Kind==7"`
+		}
+
+		type StructUnderTest struct {
+			Header struct {
+				Flags uint8
+			}
+			Elements []Element
+		}
+
+		expectedStruct := &StructUnderTest{Elements: []Element{{Kind: 7, Value: 1, Extra: 2}, {Kind: 3, Value: 4, Extra: 5}}}
+		expectedStruct.Header.Flags = 1
+
+		actualStruct := &StructUnderTest{}
+		err := Unmarshal([]byte{0x01, 0x07, 0x01, 0x02, 0x03, 0x04, 0x05}, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedStruct, actualStruct)
+	})
+
+	t.Run("verify includeIf dereferences pointers decoded earlier", func(t *testing.T) {
+		type Header struct {
+			HasTwo bool
+		}
+
+		type StructUnderTest struct {
+			Header *Header
+			Two    uint8 `bcincludeif:"Header.HasTwo"`
+		}
+
+		expectedStruct := &StructUnderTest{Header: &Header{HasTwo: true}, Two: 2}
+
+		actualStruct := &StructUnderTest{}
+		err := Unmarshal([]byte{0x01, 0x02}, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedStruct, actualStruct)
+	})
+
+	t.Run("verify struct defaults apply to embedded structs", func(t *testing.T) {
+		type Header struct {
+			One uint16
+		}
+
+		type StructUnderTest struct {
+			_ Defaults `bcendian:"big"`
+			Header
+		}
+
+		expectedStruct := &StructUnderTest{Header: Header{One: 0x0102}}
+
+		actualStruct := &StructUnderTest{}
+		err := Unmarshal([]byte{0x01, 0x02}, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedStruct, actualStruct)
+	})
+
+	t.Run("verify fields with ignore tag and unexported fields are left untouched", func(t *testing.T) {
+		type header struct {
+			One uint8
+		}
+
+		type StructUnderTest struct {
+			header
+			Source string `bcignore:""`
+			two    uint8
+			Three  uint8
+		}
+
+		expectedStruct := &StructUnderTest{header: header{One: 1}, Source: "source", two: 2, Three: 3}
+
+		actualStruct := &StructUnderTest{Source: "source", two: 2}
+		err := Unmarshal([]byte{0x01, 0x03}, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedStruct, actualStruct)
+	})
+
 	t.Run("supports pointers which implement Unmarshaller interface", func(t *testing.T) {
 		type StructUnderTest struct {
 			One *CustomField
@@ -541,4 +864,311 @@ func TestUnmarshall(t *testing.T) {
 		assert.NotNil(t, instance.One)
 		assert.Equal(t, uint8(1), instance.One.Value)
 	})
+
+	t.Run("supports values whose pointer implements Unmarshaller interface", func(t *testing.T) {
+		type StructUnderTest struct {
+			One CustomField
+		}
+
+		instance := &StructUnderTest{}
+		err := Unmarshal([]byte{0x03, 'O', 'N', 'E'}, instance)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(1), instance.One.Value)
+	})
+
+	t.Run("unmarshals the root value by its fields, so Unmarshallers can delegate with themselves", func(t *testing.T) {
+		field := &DelegatingField{}
+		err := Unmarshal([]byte{0x01}, field)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(0x01), field.Value)
+
+		type StructUnderTest struct {
+			One DelegatingField
+		}
+
+		instance := &StructUnderTest{}
+		err = Unmarshal([]byte{0xaa, 0x01}, instance)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(0x01), instance.One.Value)
+	})
+}
+
+func TestTryUnmarshal(t *testing.T) {
+	type Long struct {
+		Marker uint8
+		Value  uint32
+	}
+
+	type Short struct {
+		Marker uint8
+		Value  uint8
+	}
+
+	t.Run("verify buffer is restored on failure so another type can be attempted", func(t *testing.T) {
+		bb := bitbuffer.NewBitBufferFromBytes([]byte{0x01, 0x02})
+
+		long := &Long{}
+		err := TryUnmarshal(bb, long)
+		assert.Error(t, err)
+
+		short := &Short{}
+		err = TryUnmarshal(bb, short)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &Short{Marker: 0x01, Value: 0x02}, short)
+	})
+
+	t.Run("verify buffer is consumed on success", func(t *testing.T) {
+		bb := bitbuffer.NewBitBufferFromBytes([]byte{0x01, 0x02, 0x03})
+
+		err := TryUnmarshal(bb, &Short{})
+		assert.NoError(t, err)
+
+		remaining, err := bb.ReadByte()
+
+		assert.NoError(t, err)
+		assert.Equal(t, byte(0x03), remaining)
+	})
+
+	t.Run("verify buffer is restored when failing within a partially read byte", func(t *testing.T) {
+		type Bits struct {
+			High uint8 `bcfieldwidth:"4"`
+			Rest uint16
+		}
+
+		bb := bitbuffer.NewBitBufferFromBytes([]byte{0xab, 0xcd})
+
+		_, _ = bb.ReadBits(2)
+
+		err := TryUnmarshal(bb, &Bits{})
+		assert.Error(t, err)
+
+		actualValue, err := bb.ReadBits(6)
+
+		assert.NoError(t, err)
+		assert.Equal(t, byte(0x2b), actualValue)
+	})
+}
+
+func TestZeroCopy(t *testing.T) {
+	t.Run("verify aligned byte slices share memory with the input", func(t *testing.T) {
+		type StructUnderTest struct {
+			Header  uint8
+			Payload []byte `bcsliceprefix:"8"`
+			Rest    []byte
+		}
+
+		data := []byte{0x01, 0x02, 0xaa, 0xbb, 0xcc}
+
+		actualStruct := &StructUnderTest{}
+		err := Unmarshal(data, actualStruct, ZeroCopy())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xaa, 0xbb}, actualStruct.Payload)
+		assert.Equal(t, []byte{0xcc}, actualStruct.Rest)
+
+		data[2] = 0xff
+		assert.Equal(t, byte(0xff), actualStruct.Payload[0])
+
+		actualStruct.Payload = append(actualStruct.Payload, 0x00)
+		assert.Equal(t, byte(0xcc), data[4])
+	})
+
+	t.Run("verify unaligned byte slices are copied", func(t *testing.T) {
+		type StructUnderTest struct {
+			Flags   uint8 `bcfieldwidth:"4"`
+			Payload []byte
+		}
+
+		data := []byte{0x1a, 0xb0}
+
+		actualStruct := &StructUnderTest{}
+		err := Unmarshal(data, actualStruct, ZeroCopy())
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xab}, actualStruct.Payload)
+
+		data[0] = 0x00
+		assert.Equal(t, []byte{0xab}, actualStruct.Payload)
+	})
+
+	t.Run("verify byte slices are copied without the option", func(t *testing.T) {
+		type StructUnderTest struct {
+			Payload []byte
+		}
+
+		data := []byte{0xaa}
+
+		actualStruct := &StructUnderTest{}
+		err := Unmarshal(data, actualStruct)
+
+		assert.NoError(t, err)
+
+		data[0] = 0xff
+		assert.Equal(t, []byte{0xaa}, actualStruct.Payload)
+	})
+}
+
+func TestInternStrings(t *testing.T) {
+	t.Run("verify strings of each type are unmarshalled through the interner", func(t *testing.T) {
+		type StructUnderTest struct {
+			Prefixed string
+			Varint   string `bcstringtype:"prefix,varint"`
+			Null     string `bcstringtype:"null"`
+			Invalid  string `bcstringtype:"prefix,8,invalid"`
+			Length   uint8  `bclength:"Raw"`
+			Raw      string
+		}
+
+		interner := NewStringInterner(0)
+
+		data := []byte{0x02, 'o', 'n', 0x02, 'o', 'n', 'o', 'f', 'f', 0x00, 0xff, 0x03, 'o', 'f', 'f'}
+
+		actualStruct := &StructUnderTest{}
+		err := Unmarshal(data, actualStruct, InternStrings(interner))
+
+		assert.NoError(t, err)
+		assert.Equal(t, &StructUnderTest{Prefixed: "on", Varint: "on", Null: "off", Invalid: "", Length: 3, Raw: "off"}, actualStruct)
+		assert.Equal(t, 2, interner.Len())
+	})
+
+	t.Run("verify the interner stops retaining strings at its limit", func(t *testing.T) {
+		interner := NewStringInterner(1)
+
+		assert.Equal(t, "one", interner.Intern([]byte("one")))
+		assert.Equal(t, "two", interner.Intern([]byte("two")))
+		assert.Equal(t, 1, interner.Len())
+	})
+
+	t.Run("verify interned strings are read without allocating", func(t *testing.T) {
+		type StructUnderTest struct {
+			Name string
+		}
+
+		interner := NewStringInterner(0)
+		data := []byte{0x04, 'l', 'a', 'm', 'p'}
+		actualStruct := &StructUnderTest{}
+
+		withInterner := InternStrings(interner)
+		_ = Unmarshal(data, actualStruct, withInterner)
+
+		bb := bitbuffer.NewBitBufferFromBytes(data)
+		stringTag, _ := tagStringType("", false)
+		value := reflect.ValueOf(actualStruct).Elem().Field(0)
+
+		allocs := testing.AllocsPerRun(10, func() {
+			_ = bb.SeekBits(0)
+			_ = unmarshalInternedString(bb, interner, value, stringTag)
+		})
+
+		assert.Equal(t, float64(0), allocs)
+		assert.Equal(t, "lamp", actualStruct.Name)
+	})
+}
+
+func TestUnmarshalReuse(t *testing.T) {
+	t.Run("verify slices are unmarshalled into their existing capacity", func(t *testing.T) {
+		type StructUnderTest struct {
+			Bytes  []byte `bcsliceprefix:"8"`
+			Values []uint16
+		}
+
+		actualStruct := &StructUnderTest{Bytes: make([]byte, 4, 8), Values: make([]uint16, 0, 8)}
+		bytes, values := &actualStruct.Bytes[:1][0], &actualStruct.Values[:1][0]
+
+		err := Unmarshal([]byte{0x02, 0xaa, 0xbb, 0x01, 0x00, 0x02, 0x00}, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0xaa, 0xbb}, actualStruct.Bytes)
+		assert.Equal(t, []uint16{0x01, 0x02}, actualStruct.Values)
+		assert.Equal(t, 8, cap(actualStruct.Bytes))
+		assert.Same(t, bytes, &actualStruct.Bytes[0])
+		assert.Same(t, values, &actualStruct.Values[0])
+	})
+
+	t.Run("verify slices are sized from their prefix", func(t *testing.T) {
+		type StructUnderTest struct {
+			Values []uint16 `bcsliceprefix:"8"`
+		}
+
+		actualStruct := &StructUnderTest{}
+		err := Unmarshal([]byte{0x03, 0x01, 0x00, 0x02, 0x00, 0x03, 0x00}, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uint16{0x01, 0x02, 0x03}, actualStruct.Values)
+		assert.Equal(t, 3, cap(actualStruct.Values))
+	})
+
+	type OptionalElement struct {
+		HasValue bool
+		Value    uint8 `bcincludeif:"HasValue"`
+	}
+
+	t.Run("verify reused slice elements are zeroed without overwrite", func(t *testing.T) {
+		type StructUnderTest struct {
+			Elements []OptionalElement `bcsliceprefix:"8"`
+		}
+
+		actualStruct := &StructUnderTest{Elements: []OptionalElement{{HasValue: true, Value: 0x55}}}
+		err := Unmarshal([]byte{0x01, 0x00}, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []OptionalElement{{}}, actualStruct.Elements)
+	})
+
+	t.Run("verify excluded fields are retained without overwrite", func(t *testing.T) {
+		actualStruct := &OptionalElement{Value: 0x01}
+		err := Unmarshal([]byte{0x00}, actualStruct)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &OptionalElement{Value: 0x01}, actualStruct)
+	})
+
+	t.Run("verify excluded fields are zeroed with overwrite", func(t *testing.T) {
+		type StructUnderTest struct {
+			HasValue bool
+			Value    []uint8 `bcincludeif:"HasValue" bcsliceprefix:"8"`
+			Other    uint8
+		}
+
+		actualStruct := &StructUnderTest{Value: []uint8{0x01}, Other: 0x02}
+		err := Unmarshal([]byte{0x00, 0x03}, actualStruct, Overwrite())
+
+		assert.NoError(t, err)
+		assert.Equal(t, &StructUnderTest{Other: 0x03}, actualStruct)
+	})
+
+	t.Run("verify unmarshalling into a reused value with overwrite does not allocate", func(t *testing.T) {
+		if raceEnabled {
+			t.Skip("pooled values are dropped at random under the race detector")
+		}
+
+		type Element struct {
+			Kind  uint8
+			Value []byte `bcsliceprefix:"8"`
+		}
+
+		type StructUnderTest struct {
+			Bytes    []byte    `bcsliceprefix:"8"`
+			Elements []Element `bcsliceprefix:"8"`
+		}
+
+		data := []byte{0x01, 0xaa, 0x01, 0x05, 0x01, 0xcc}
+		opts := []Option{Overwrite()}
+
+		actualStruct := &StructUnderTest{}
+		err := Unmarshal(data, actualStruct, opts...)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &StructUnderTest{Bytes: []byte{0xaa}, Elements: []Element{{Kind: 0x05, Value: []byte{0xcc}}}}, actualStruct)
+
+		allocs := testing.AllocsPerRun(100, func() {
+			_ = Unmarshal(data, actualStruct, opts...)
+		})
+
+		assert.Equal(t, float64(0), allocs)
+	})
 }
@@ -0,0 +1,557 @@
+package bytecodec
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+var ErrInvalidDefinition = errors.New("invalid definition")
+
+// ValidationError lists every problem found by Validate, each naming the path of the field it was found on.
+type ValidationError struct {
+	Type     reflect.Type
+	Problems []error
+}
+
+func (e *ValidationError) Error() string {
+	problems := make([]string, len(e.Problems))
+
+	for i, problem := range e.Problems {
+		problems[i] = problem.Error()
+	}
+
+	return fmt.Sprintf("%v: '%v': %s", ErrInvalidDefinition, e.Type, strings.Join(problems, "; "))
+}
+
+// Is reports a ValidationError as ErrInvalidDefinition, and as any error one of its problems is.
+func (e *ValidationError) Is(target error) bool {
+	if target == ErrInvalidDefinition {
+		return true
+	}
+
+	for _, problem := range e.Problems {
+		if errors.Is(problem, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Validate checks that a type can be marshalled and unmarshalled, by checking every tag, the paths of bcincludeif
+// conditions, that tags are used on fields of a suitable type, and that fields are referred to only by later fields.
+// The type may be given as a reflect.Type, a value or a pointer to a value. All problems are returned together in a
+// *ValidationError. Of the options, only LenientTags affects validation.
+func Validate(v interface{}, opts ...Option) error {
+	t, ok := v.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(v)
+	}
+
+	if t == nil {
+		return fmt.Errorf("%w: can not validate nil", ErrInvalidDefinition)
+	}
+
+	for t.Kind() == reflect.Ptr && !t.Implements(marshalerType) {
+		t = t.Elem()
+	}
+
+	o := &options{}
+	applyOptions(o, opts)
+
+	val := &validator{root: t, lenient: o.lenientTags, seen: map[validatedKey]bool{}, depths: map[reflect.Type]int{}, typeIDs: map[reflect.Type]int{}}
+	val.validateType(t, t.Name(), "", "", nil)
+
+	if len(val.problems) > 0 {
+		return &ValidationError{Type: t, Problems: val.problems}
+	}
+
+	return nil
+}
+
+// MustValidate validates a type as Validate, panicking if there are any problems, for use at initialisation.
+func MustValidate(v interface{}, opts ...Option) {
+	if err := Validate(v, opts...); err != nil {
+		panic(err)
+	}
+}
+
+type validator struct {
+	root     reflect.Type
+	lenient  bool
+	seen     map[validatedKey]bool
+	depths   map[reflect.Type]int
+	typeIDs  map[reflect.Type]int
+	problems []error
+}
+
+// validatedKey identifies a struct type validated within the enclosing structs its bcincludeif paths can refer to, as
+// the same type may be valid within some ancestors and not others.
+type validatedKey struct {
+	structType reflect.Type
+	scope      string
+}
+
+func (v *validator) problem(path string, err error) {
+	v.problems = append(v.problems, fmt.Errorf("%s: %w", path, err))
+}
+
+// validationScope is a struct enclosing the type being validated, with the name of its field which the type is within,
+// used to resolve bcincludeif paths and check their order.
+type validationScope struct {
+	structType reflect.Type
+	field      string
+}
+
+// validateType checks a type with the tags of the field holding it, following the same path as marshalValue. The
+// scopes are the structs enclosing the type, outermost first.
+func (v *validator) validateType(t reflect.Type, path string, tags reflect.StructTag, defaults reflect.StructTag, scopes []validationScope) {
+	if t.Kind() != reflect.Ptr && len(scopes) > 0 && reflect.PtrTo(t).Implements(marshalerType) {
+		return
+	}
+
+	if _, tagPresent := tags.Lookup(TagScale); tagPresent {
+		if scaleTag, err := tagScale(tags); err != nil {
+			v.problem(path, err)
+		} else if !wireWidthSupported(int(scaleTag.Size)) {
+			v.problem(path, fmt.Errorf("%s wire width %d must be below 8 bits or whole bytes", TagScale, scaleTag.Size))
+		}
+
+		return
+	}
+
+	if _, tagPresent := tags.Lookup(TagBCD); tagPresent {
+		if _, err := tagBCD(tags); err != nil {
+			v.problem(path, err)
+		}
+
+		return
+	}
+
+	if isTimeType(t) {
+		if timeTag, err := tagTime(tags); err != nil {
+			v.problem(path, err)
+		} else if !timeTag.Present {
+			v.problem(path, fmt.Errorf("%w: %v requires a %s tag", ErrUnsupportedType, t, TagTime))
+		}
+
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Bool, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.validateFieldWidth(t, path, tags)
+	case reflect.String:
+		if stringTag, err := tagStringType(tags, v.lenient); err != nil {
+			v.problem(path, err)
+		} else if stringTag.Invalid && stringTag.Termination == Prefix {
+			v.problem(path, fmt.Errorf("%s %s on a string is read as an empty string, use a *string to keep it", TagStringType, InvalidKeyword))
+		}
+	case reflect.Array, reflect.Slice:
+		if _, err := tagSlicePrefix(tags, v.lenient); err != nil {
+			v.problem(path, err)
+		}
+
+		v.validateType(t.Elem(), path+"[]", tags, defaults, scopes)
+	case reflect.Ptr:
+		if _, nullable := nullableString(t, tags, v.lenient); nullable {
+			return
+		}
+
+		v.validateType(t.Elem(), path, tags, defaults, scopes)
+	case reflect.Struct:
+		for _, scope := range scopes {
+			if scope.structType == t {
+				return
+			}
+		}
+
+		key := v.validatedKey(t, scopes)
+		if v.seen[key] {
+			return
+		}
+
+		v.seen[key] = true
+
+		if _, isBitmap, err := findBitmapLayout(t, defaults, v.lenient); isBitmap || err != nil {
+			if err != nil {
+				v.problem(path, err)
+			}
+
+			return
+		}
+
+		v.validateFields(t, path, defaults, append(scopes[:len(scopes):len(scopes)], validationScope{structType: t}))
+	case reflect.Float32, reflect.Float64:
+		v.problem(path, fmt.Errorf("%w: %v requires a %s tag", ErrUnsupportedType, t.Kind(), TagScale))
+	default:
+		v.problem(path, fmt.Errorf("%w: %v", ErrUnsupportedType, t.Kind()))
+	}
+}
+
+// validatedKey returns the key for a struct type, including as many of the enclosing scopes as its bcincludeif paths
+// can reach.
+func (v *validator) validatedKey(t reflect.Type, scopes []validationScope) validatedKey {
+	depth := v.ancestorDepth(t)
+	if depth > len(scopes) {
+		depth = len(scopes)
+	}
+
+	var scope strings.Builder
+
+	for _, s := range scopes[len(scopes)-depth:] {
+		fmt.Fprintf(&scope, "%d.%s/", v.typeID(s.structType), s.field)
+	}
+
+	return validatedKey{structType: t, scope: scope.String()}
+}
+
+func (v *validator) typeID(t reflect.Type) int {
+	id, found := v.typeIDs[t]
+	if !found {
+		id = len(v.typeIDs)
+		v.typeIDs[t] = id
+	}
+
+	return id
+}
+
+// ancestorDepth returns how many structs enclosing a struct type the bcincludeif paths within it can refer to, with
+// absolute paths able to refer to all of them.
+func (v *validator) ancestorDepth(t reflect.Type) int {
+	if depth, found := v.depths[t]; found {
+		return depth
+	}
+
+	v.depths[t] = 0
+
+	depth := v.fieldsAncestorDepth(t)
+	v.depths[t] = depth
+
+	return depth
+}
+
+func (v *validator) fieldsAncestorDepth(structType reflect.Type) int {
+	depth := 0
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		if isDefaultsMarker(field) || isIgnored(field) {
+			continue
+		}
+
+		if isFlattened(field) {
+			depth = maxInt(depth, v.fieldsAncestorDepth(field.Type))
+			continue
+		}
+
+		if includeIf, err := tagIncludeIf(field.Tag); err == nil && includeIf.HasIncludeIf() {
+			if !includeIf.Relative {
+				return math.MaxInt32
+			}
+
+			depth = maxInt(depth, includeIf.Ancestor)
+		}
+
+		elemType := field.Type
+		for elemType.Kind() == reflect.Ptr || elemType.Kind() == reflect.Slice || elemType.Kind() == reflect.Array {
+			elemType = elemType.Elem()
+		}
+
+		if elemType.Kind() == reflect.Struct {
+			depth = maxInt(depth, v.ancestorDepth(elemType)-1)
+		}
+	}
+
+	return depth
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
+
+func (v *validator) validateFieldWidth(t reflect.Type, path string, tags reflect.StructTag) {
+	fieldWidth, err := tagFieldWidth(tags, v.lenient)
+	if err != nil {
+		v.problem(path, fmt.Errorf("'%s' is not a valid %s: %w", tags.Get(TagFieldWidth), TagFieldWidth, err))
+		return
+	}
+
+	switch {
+	case isInt(t.Kind()) && !fieldWidth.Varint:
+		v.problem(path, fmt.Errorf("%w: signed %v must have a %s of %s", ErrUnsupportedType, t, TagFieldWidth, VarintKeyword))
+	case fieldWidth.Default:
+	case fieldWidth.Varint:
+		if t.Kind() == reflect.Bool {
+			v.problem(path, fmt.Errorf("%s varint can not be used on a bool", TagFieldWidth))
+		}
+	case fieldWidth.BitWidth <= 0:
+		v.problem(path, fmt.Errorf("%s %d must be at least 1 bit", TagFieldWidth, fieldWidth.BitWidth))
+	case t.Kind() != reflect.Bool && fieldWidth.BitWidth > t.Bits():
+		v.problem(path, fmt.Errorf("%s %d is wider than the %d bit %v", TagFieldWidth, fieldWidth.BitWidth, t.Bits(), t))
+	case fieldWidth.BitWidth > 64:
+		v.problem(path, fmt.Errorf("%s %d is wider than 64 bits", TagFieldWidth, fieldWidth.BitWidth))
+	case t.Kind() != reflect.Bool && !wireWidthSupported(fieldWidth.BitWidth):
+		v.problem(path, fmt.Errorf("%s %d must be below 8 bits or whole bytes", TagFieldWidth, fieldWidth.BitWidth))
+	}
+}
+
+// wireWidthSupported returns if an unsigned integer of a width in bits can be written by BitBuffer.WriteUint, which
+// writes widths below 8 bits as bits and wider widths only as whole bytes.
+func wireWidthSupported(bits int) bool {
+	return bits < 8 || bits%8 == 0
+}
+
+// validateFields checks the fields of a struct, the last of the scopes is the struct which relative bcincludeif paths
+// are resolved from, which differs from the struct for embedded structs.
+func (v *validator) validateFields(structType reflect.Type, path string, defaults reflect.StructTag, scopes []validationScope) {
+	defaults = structDefaults(structType, defaults)
+
+	if _, err := tagStringType(defaults, v.lenient); err != nil {
+		v.problem(path, err)
+	}
+
+	if _, err := tagEndianness(defaults, v.lenient); err != nil {
+		v.problem(path, err)
+	}
+
+	if _, _, err := findChecksums(structType); err != nil {
+		v.problem(path, err)
+	}
+
+	if _, _, err := findLengths(structType); err != nil {
+		v.problem(path, err)
+	}
+
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		if isDefaultsMarker(field) || isIgnored(field) {
+			continue
+		}
+
+		fieldPath := path + "." + field.Name
+
+		if isFlattened(field) {
+			v.validateFields(field.Type, fieldPath, defaults, scopes)
+			continue
+		}
+
+		last := len(scopes) - 1
+		fieldScopes := append(scopes[:last:last], validationScope{structType: scopes[last].structType, field: field.Name})
+
+		if _, err := tagEndianness(field.Tag, v.lenient); err != nil {
+			v.problem(fieldPath, err)
+		}
+
+		v.validateIncludeIf(fieldPath, field, fieldScopes)
+		v.validateTagUsage(fieldPath, field)
+		v.validateType(field.Type, fieldPath, withDefaults(field.Tag, defaults), defaults, fieldScopes)
+	}
+}
+
+// validateTagUsage checks that the tags on a field are used with a type they apply to, as otherwise they would be
+// silently ignored.
+func (v *validator) validateTagUsage(path string, field reflect.StructField) {
+	if reflect.PtrTo(field.Type).Implements(marshalerType) {
+		return
+	}
+
+	fieldType := field.Type
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	elemType := fieldType
+	for elemType.Kind() == reflect.Ptr || elemType.Kind() == reflect.Slice || elemType.Kind() == reflect.Array {
+		elemType = elemType.Elem()
+	}
+
+	elemKind := elemType.Kind()
+
+	checks := []struct {
+		tag   string
+		valid bool
+		want  string
+	}{
+		{TagSlicePrefix, fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Array, "a slice or array"},
+		{TagStringType, elemKind == reflect.String, "a string"},
+		{TagTime, isTimeType(elemType), "a time.Time or time.Duration"},
+		{TagScale, field.Type.Kind() == reflect.Float32 || field.Type.Kind() == reflect.Float64, "a float"},
+		{TagBCD, isUint(field.Type.Kind()) || field.Type.Kind() == reflect.String, "an unsigned integer or string"},
+		{TagFieldWidth, elemKind == reflect.Bool || isUint(elemKind) || isInt(elemKind), "a bool or integer"},
+		{TagBit, false, "a field of a bitmap struct"},
+	}
+
+	for _, check := range checks {
+		if _, tagPresent := field.Tag.Lookup(check.tag); tagPresent && !check.valid {
+			v.problem(path, fmt.Errorf("%s can only be used on %s, not %v", check.tag, check.want, field.Type))
+		}
+	}
+}
+
+func isInt(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	default:
+		return false
+	}
+}
+
+// validateIncludeIf checks that a bcincludeif path refers to a bool or unsigned integer field which is encoded before
+// the field, and that its value can be compared with the field.
+func (v *validator) validateIncludeIf(path string, field reflect.StructField, scopes []validationScope) {
+	includeIf, err := tagIncludeIf(field.Tag)
+	if err != nil {
+		v.problem(path, err)
+		return
+	}
+
+	if !includeIf.HasIncludeIf() {
+		return
+	}
+
+	includePath := strings.Join(includeIf.FieldPath, ".")
+	level := len(scopes) - 1 - includeIf.Ancestor
+
+	if !includeIf.Relative {
+		if v.root.Kind() != reflect.Struct {
+			v.problem(path, fmt.Errorf("%s absolute path '%s' requires the root to be a struct", TagIncludeIf, includePath))
+			return
+		}
+
+		level = 0
+	}
+
+	if level < 0 {
+		v.problem(path, fmt.Errorf("%s path '%s' is %d levels above a field %d levels deep", TagIncludeIf, includePath, includeIf.Ancestor, len(scopes)-1))
+		return
+	}
+
+	base, current := scopes[level].structType, scopes[level].field
+	targetType := base
+	previous := ""
+
+	for _, part := range includeIf.FieldPath {
+		name, indexes := splitPathIndexes(part)
+
+		if targetType.Kind() != reflect.Struct {
+			v.problem(path, fmt.Errorf("%s path '%s' can not be followed, %s is not a struct", TagIncludeIf, includePath, previous))
+			return
+		}
+
+		targetField, found := findFieldType(targetType, name)
+		if !found {
+			v.problem(path, fmt.Errorf("%s path '%s' can not be followed, %s not found", TagIncludeIf, includePath, name))
+			return
+		}
+
+		if targetType, err = followPathIndexTypes(targetField.Type, indexes); err != nil {
+			v.problem(path, fmt.Errorf("%s path '%s' can not be followed, %s: %w", TagIncludeIf, includePath, part, err))
+			return
+		}
+
+		previous = part
+	}
+
+	order := fieldOrder(base)
+	target, _ := splitPathIndexes(includeIf.FieldPath[0])
+	innermost := level == len(scopes)-1
+
+	if referenced, position := order[target], order[current]; referenced >= position && (innermost || target != current) {
+		v.problem(path, fmt.Errorf("%s path '%s' must refer to an earlier field", TagIncludeIf, includePath))
+		return
+	}
+
+	switch {
+	case targetType.Kind() == reflect.Bool:
+		if _, err := includeIf.boolValue(); err != nil {
+			v.problem(path, fmt.Errorf("%s value '%s' is not a bool", TagIncludeIf, includeIf.Value))
+		}
+	case isUint(targetType.Kind()):
+		if _, err := includeIf.uintValue(targetType); err != nil {
+			v.problem(path, fmt.Errorf("%s value '%s' is not a number or name of %v", TagIncludeIf, includeIf.Value, targetType))
+		}
+	default:
+		v.problem(path, fmt.Errorf("%s path '%s' must refer to a bool or unsigned integer, not %v", TagIncludeIf, includePath, targetType))
+	}
+}
+
+// followPathIndexTypes finds the type reached by the indexes of a path part, as followPathIndexes.
+func followPathIndexTypes(t reflect.Type, indexes string) (reflect.Type, error) {
+	for {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		if indexes == "" {
+			return t, nil
+		}
+
+		index, remaining, err := nextPathIndex(indexes)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case t.Kind() == reflect.Array && index >= t.Len():
+			return nil, fmt.Errorf("index %d is out of range of %v", index, t)
+		case t.Kind() != reflect.Array && t.Kind() != reflect.Slice:
+			return nil, fmt.Errorf("%v is not an array or slice", t)
+		}
+
+		t = t.Elem()
+		indexes = remaining
+	}
+}
+
+// fieldOrder returns the position of each field of a struct in the order they are encoded, with the fields of
+// embedded structs in place of the embedded struct.
+func fieldOrder(structType reflect.Type) map[string]int {
+	order := map[string]int{}
+	addFieldOrder(structType, order)
+	return order
+}
+
+func addFieldOrder(structType reflect.Type, order map[string]int) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+
+		if isFlattened(field) {
+			addFieldOrder(field.Type, order)
+			continue
+		}
+
+		if _, found := order[field.Name]; !found {
+			order[field.Name] = len(order)
+		}
+	}
+}
+
+// findFieldType finds a field of a struct type by name, as findField.
+func findFieldType(structType reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < structType.NumField(); i++ {
+		if structType.Field(i).Name == name {
+			return structType.Field(i), true
+		}
+	}
+
+	for i := 0; i < structType.NumField(); i++ {
+		if isFlattened(structType.Field(i)) {
+			if field, found := findFieldType(structType.Field(i).Type, name); found {
+				return field, true
+			}
+		}
+	}
+
+	return reflect.StructField{}, false
+}
@@ -0,0 +1,290 @@
+package bytecodec
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	type Header struct {
+		Flags   uint8
+		Version uint8 `bcfieldwidth:"4"`
+		Spare   uint8 `bcfieldwidth:"4"`
+	}
+
+	type Nested struct {
+		HasName bool
+		Name    string `bcincludeif:"HasName" bcstringtype:"null"`
+		Extra   uint16 `bcincludeif:".Header.Flags==1"`
+	}
+
+	type Valid struct {
+		_       Defaults `bcendian:"big"`
+		Header  Header
+		Length  uint8     `bclength:"Payload"`
+		Time    time.Time `bctime:"zcl,s,32"`
+		Nested  []Nested  `bcsliceprefix:"8"`
+		Level   float32   `bcscale:"uint8,div=2"`
+		Payload []byte
+		Ignored map[string]string `bcignore:"true"`
+	}
+
+	t.Run("verify a valid struct has no problems", func(t *testing.T) {
+		assert.NoError(t, Validate(Valid{}))
+		assert.NoError(t, Validate(&Valid{}))
+		assert.NoError(t, Validate(reflect.TypeOf(Valid{})))
+		assert.NotPanics(t, func() { MustValidate(Valid{}) })
+	})
+
+	t.Run("verify embedded structs can be referred to", func(t *testing.T) {
+		type Embedded struct {
+			Header
+			Value uint8 `bcincludeif:"Flags"`
+		}
+
+		assert.NoError(t, Validate(Embedded{}))
+	})
+
+	t.Run("verify all problems are returned together", func(t *testing.T) {
+		type Invalid struct {
+			Width     uint8   `bcfieldwidth:"abc"`
+			Wide      uint8   `bcfieldwidth:"9"`
+			Prefix    []uint8 `bcsliceprefix:"x"`
+			Missing   uint8   `bcincludeif:"Absent"`
+			Later     uint8   `bcincludeif:"Flag"`
+			Flag      bool
+			Text      string
+			Compare   uint8 `bcincludeif:"Text"`
+			Mode      uint8
+			BadValue  uint8 `bcincludeif:"Mode==on"`
+			Misplaced uint8 `bcstringtype:"null"`
+			Values    map[string]uint8
+			Level     float32
+			Endian    uint16 `bcendian:"Big"`
+		}
+
+		err := Validate(Invalid{})
+
+		validationErr := &ValidationError{}
+		assert.True(t, errors.As(err, &validationErr))
+		assert.True(t, errors.Is(err, ErrInvalidDefinition))
+		assert.True(t, errors.Is(err, ErrUnsupportedType))
+
+		expectedPaths := []string{
+			"Invalid.Width:",
+			"Invalid.Wide:",
+			"Invalid.Prefix:",
+			"Invalid.Missing:",
+			"Invalid.Later:",
+			"Invalid.Compare:",
+			"Invalid.BadValue:",
+			"Invalid.Misplaced:",
+			"Invalid.Values:",
+			"Invalid.Level:",
+			"Invalid.Endian:",
+		}
+
+		assert.Len(t, validationErr.Problems, len(expectedPaths))
+
+		for i, problem := range validationErr.Problems {
+			assert.Contains(t, problem.Error(), expectedPaths[i])
+		}
+
+		assert.Panics(t, func() { MustValidate(Invalid{}) })
+	})
+
+	t.Run("verify problems in nested structs are named by path", func(t *testing.T) {
+		type Inner struct {
+			Value uint8 `bcincludeif:".Missing"`
+		}
+
+		type Outer struct {
+			Inners []Inner `bcsliceprefix:"8"`
+		}
+
+		err := Validate(Outer{})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Outer.Inners[].Value: bcincludeif path 'Missing' can not be followed")
+	})
+
+	t.Run("verify ancestor and indexed paths are followed", func(t *testing.T) {
+		type Element struct {
+			Kind  uint8
+			Value uint8 `bcincludeif:"..Header.Flags==1"`
+			Extra uint8 `bcincludeif:"..Kinds[1]==2"`
+			Bad   uint8 `bcincludeif:"..Kinds[4]"`
+			Above uint8 `bcincludeif:"...Header.Flags"`
+			Later uint8 `bcincludeif:"..Trailer"`
+		}
+
+		type Outer struct {
+			Header   *Header
+			Kinds    [4]uint8
+			Elements []Element `bcsliceprefix:"8"`
+			Trailer  bool
+		}
+
+		err := Validate(Outer{})
+
+		validationErr := &ValidationError{}
+		assert.True(t, errors.As(err, &validationErr))
+
+		expectedProblems := []string{
+			"Outer.Elements[].Bad: bcincludeif path 'Kinds[4]' can not be followed",
+			"Outer.Elements[].Above: bcincludeif path 'Header.Flags' is 2 levels above",
+			"Outer.Elements[].Later: bcincludeif path 'Trailer' must refer to an earlier field",
+		}
+
+		assert.Len(t, validationErr.Problems, len(expectedProblems))
+
+		for i, problem := range validationErr.Problems {
+			assert.Contains(t, problem.Error(), expectedProblems[i])
+		}
+	})
+
+	t.Run("verify a struct is checked at each depth its ancestor paths are used from", func(t *testing.T) {
+		type Inner struct {
+			V uint8 `bcincludeif:"..Flag"`
+		}
+
+		type Mid struct {
+			X Inner
+		}
+
+		type Outer struct {
+			Flag bool
+			A    Inner
+			B    Mid
+		}
+
+		err := Validate(Outer{})
+
+		validationErr := &ValidationError{}
+		assert.True(t, errors.As(err, &validationErr))
+		assert.Len(t, validationErr.Problems, 1)
+		assert.Contains(t, validationErr.Problems[0].Error(), "Outer.B.X.V: bcincludeif path 'Flag' can not be followed")
+
+		_, marshalErr := Marshal(&Outer{})
+		assert.Error(t, marshalErr)
+	})
+
+	t.Run("verify recursive structs are validated", func(t *testing.T) {
+		type Node struct {
+			HasValue bool
+			Value    uint8  `bcincludeif:"HasValue"`
+			Children []Node `bcsliceprefix:"8"`
+		}
+
+		assert.NoError(t, Validate(Node{}))
+	})
+
+	t.Run("verify malformed include if tags are reported", func(t *testing.T) {
+		type Malformed struct {
+			Value uint8 `bcincludeif:""`
+		}
+
+		assert.Error(t, Validate(Malformed{}))
+	})
+
+	t.Run("verify checksum, length and bitmap problems are reported", func(t *testing.T) {
+		type Bits struct {
+			_    Bitmap `bcbitmap:"8"`
+			High bool   `bcbit:"9"`
+		}
+
+		type Invalid struct {
+			Sum    uint8 `bcchecksum:"xor,Later"`
+			Later  uint8
+			Length uint8 `bclength:"Absent"`
+			Bits   Bits
+			Stray  bool `bcbit:"1"`
+		}
+
+		err := Validate(Invalid{})
+
+		validationErr := &ValidationError{}
+		assert.True(t, errors.As(err, &validationErr))
+		assert.Len(t, validationErr.Problems, 4)
+	})
+
+	t.Run("verify definitions which marshal always rejects are reported", func(t *testing.T) {
+		type Invalid struct {
+			Time     time.Time
+			Duration time.Duration
+			Signed   int16
+			Fixed    int8    `bcfieldwidth:"8"`
+			Uneven   uint16  `bcfieldwidth:"12"`
+			Scaled   float32 `bcscale:"uint12"`
+			Count    uint8   `bclength:"Value,count"`
+			Value    uint16
+		}
+
+		err := Validate(Invalid{})
+
+		validationErr := &ValidationError{}
+		assert.True(t, errors.As(err, &validationErr))
+
+		expectedPaths := []string{
+			"Invalid:",
+			"Invalid.Time:",
+			"Invalid.Duration:",
+			"Invalid.Signed:",
+			"Invalid.Fixed:",
+			"Invalid.Uneven:",
+			"Invalid.Scaled:",
+		}
+
+		assert.Len(t, validationErr.Problems, len(expectedPaths))
+
+		for i, problem := range validationErr.Problems {
+			assert.Contains(t, problem.Error(), expectedPaths[i])
+		}
+
+		for _, instance := range []interface{}{
+			&struct{ Time time.Time }{},
+			&struct{ Duration time.Duration }{},
+			&struct{ Signed int16 }{},
+			&struct {
+				Uneven uint16 `bcfieldwidth:"12"`
+			}{},
+			&struct {
+				Scaled float32 `bcscale:"uint12"`
+			}{},
+		} {
+			_, err := Marshal(instance)
+			assert.Error(t, err)
+		}
+
+		type Valid struct {
+			Signed int16   `bcfieldwidth:"varint"`
+			Narrow uint8   `bcfieldwidth:"5"`
+			Scaled float32 `bcscale:"int4"`
+			Wide   float64 `bcscale:"uint24"`
+		}
+
+		assert.NoError(t, Validate(Valid{}))
+	})
+
+	t.Run("verify invalid strings which can not be read back as invalid are reported", func(t *testing.T) {
+		type Invalid struct {
+			Name string `bcstringtype:"prefix,8,invalid"`
+		}
+
+		err := Validate(Invalid{})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Invalid.Name: bcstringtype invalid on a string is read as an empty string, use a *string")
+
+		type Valid struct {
+			Name    *string `bcstringtype:"prefix,8,invalid"`
+			Payload []byte  `bcsliceprefix:"8,invalid"`
+		}
+
+		assert.NoError(t, Validate(Valid{}))
+	})
+}
@@ -0,0 +1,120 @@
+package zcltypes
+
+import (
+	"github.com/shimmeringbee/bytecodec"
+	"github.com/shimmeringbee/bytecodec/bitbuffer"
+)
+
+type Array struct {
+	ElementType TypeID
+	Values      []interface{}
+}
+
+type Set Array
+type Bag Array
+
+type Structure []TypedValue
+
+type collection struct {
+	ElementType TypeID
+	Elements    []element `bcsliceprefix:"16,invalid"`
+}
+
+type element struct {
+	value interface{}
+}
+
+func (e *element) Marshal(bb *bitbuffer.BitBuffer, ctx bytecodec.Context) error {
+	return writeValue(bb, ctx, elementType(ctx), e.value)
+}
+
+func (e *element) Unmarshal(bb *bitbuffer.BitBuffer, ctx bytecodec.Context) (err error) {
+	e.value, err = readValue(bb, ctx, elementType(ctx))
+	return
+}
+
+func elementType(ctx bytecodec.Context) TypeID {
+	return TypeID(ctx.Root.FieldByName("ElementType").Uint())
+}
+
+func marshalCollection(bb *bitbuffer.BitBuffer, ctx bytecodec.Context, a Array) error {
+	c := collection{ElementType: a.ElementType}
+
+	if a.Values != nil {
+		c.Elements = make([]element, len(a.Values))
+
+		for i, value := range a.Values {
+			c.Elements[i].value = value
+		}
+	}
+
+	return ctx.MarshalToBitBuffer(bb, &c)
+}
+
+func unmarshalCollection(bb *bitbuffer.BitBuffer, ctx bytecodec.Context) (Array, error) {
+	c := collection{}
+
+	if err := ctx.UnmarshalFromBitBuffer(bb, &c); err != nil {
+		return Array{}, err
+	}
+
+	a := Array{ElementType: c.ElementType}
+
+	if c.Elements != nil {
+		a.Values = make([]interface{}, len(c.Elements))
+
+		for i, e := range c.Elements {
+			a.Values[i] = e.value
+		}
+	}
+
+	return a, nil
+}
+
+func (a *Array) Marshal(bb *bitbuffer.BitBuffer, ctx bytecodec.Context) error {
+	return marshalCollection(bb, ctx, *a)
+}
+
+func (a *Array) Unmarshal(bb *bitbuffer.BitBuffer, ctx bytecodec.Context) (err error) {
+	*a, err = unmarshalCollection(bb, ctx)
+	return
+}
+
+func (s *Set) Marshal(bb *bitbuffer.BitBuffer, ctx bytecodec.Context) error {
+	return marshalCollection(bb, ctx, Array(*s))
+}
+
+func (s *Set) Unmarshal(bb *bitbuffer.BitBuffer, ctx bytecodec.Context) error {
+	a, err := unmarshalCollection(bb, ctx)
+	*s = Set(a)
+	return err
+}
+
+func (b *Bag) Marshal(bb *bitbuffer.BitBuffer, ctx bytecodec.Context) error {
+	return marshalCollection(bb, ctx, Array(*b))
+}
+
+func (b *Bag) Unmarshal(bb *bitbuffer.BitBuffer, ctx bytecodec.Context) error {
+	a, err := unmarshalCollection(bb, ctx)
+	*b = Bag(a)
+	return err
+}
+
+type structure struct {
+	Members []TypedValue `bcsliceprefix:"16,invalid"`
+}
+
+func (s *Structure) Marshal(bb *bitbuffer.BitBuffer, ctx bytecodec.Context) error {
+	return ctx.MarshalToBitBuffer(bb, &structure{Members: *s})
+}
+
+func (s *Structure) Unmarshal(bb *bitbuffer.BitBuffer, ctx bytecodec.Context) error {
+	members := structure{}
+
+	if err := ctx.UnmarshalFromBitBuffer(bb, &members); err != nil {
+		return err
+	}
+
+	*s = members.Members
+	return nil
+}
@@ -0,0 +1,131 @@
+package zcltypes
+
+import (
+	"testing"
+
+	"github.com/shimmeringbee/bytecodec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollections(t *testing.T) {
+	t.Run("verify array is encoded as element type, count and elements", func(t *testing.T) {
+		value := TypedValue{Type: TypeArray, Value: Array{ElementType: TypeUnsignedInt16, Values: []interface{}{uint16(0x0102), uint16(0x0304)}}}
+
+		data, err := bytecodec.Marshal(&typedValueFrame{Value: value})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x48, 0x21, 0x02, 0x00, 0x02, 0x01, 0x04, 0x03}, data)
+
+		actualFrame := typedValueFrame{}
+		err = bytecodec.Unmarshal(data, &actualFrame)
+
+		assert.NoError(t, err)
+		assert.Equal(t, value, actualFrame.Value)
+	})
+
+	t.Run("verify set and bag round trip", func(t *testing.T) {
+		values := []TypedValue{
+			{Type: TypeSet, Value: Set{ElementType: TypeEnum8, Values: []interface{}{Enum8(1), Enum8(2)}}},
+			{Type: TypeBag, Value: Bag{ElementType: TypeStringCharacter8, Values: []interface{}{CharacterString("a"), CharacterString("a")}}},
+		}
+
+		for _, value := range values {
+			data, err := bytecodec.Marshal(&typedValueFrame{Value: value})
+			assert.NoError(t, err)
+
+			actualFrame := typedValueFrame{}
+			err = bytecodec.Unmarshal(data, &actualFrame)
+
+			assert.NoError(t, err)
+			assert.Equal(t, value, actualFrame.Value)
+		}
+	})
+
+	t.Run("verify array with nil values is encoded with invalid count", func(t *testing.T) {
+		value := TypedValue{Type: TypeArray, Value: Array{ElementType: TypeUnsignedInt8}}
+
+		data, err := bytecodec.Marshal(&typedValueFrame{Value: value})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x48, 0x20, 0xff, 0xff}, data)
+
+		actualFrame := typedValueFrame{}
+		err = bytecodec.Unmarshal(data, &actualFrame)
+
+		assert.NoError(t, err)
+		assert.Nil(t, actualFrame.Value.Value.(Array).Values)
+	})
+
+	t.Run("verify structure is encoded as count and typed members", func(t *testing.T) {
+		value := TypedValue{Type: TypeStructure, Value: Structure{
+			{Type: TypeUnsignedInt8, Value: uint8(0x01)},
+			{Type: TypeBoolean, Value: true},
+		}}
+
+		data, err := bytecodec.Marshal(&typedValueFrame{Value: value})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x4c, 0x02, 0x00, 0x20, 0x01, 0x10, 0x01}, data)
+
+		actualFrame := typedValueFrame{}
+		err = bytecodec.Unmarshal(data, &actualFrame)
+
+		assert.NoError(t, err)
+		assert.Equal(t, value, actualFrame.Value)
+	})
+
+	t.Run("verify nested collections round trip", func(t *testing.T) {
+		value := TypedValue{Type: TypeArray, Value: Array{ElementType: TypeStructure, Values: []interface{}{
+			Structure{
+				{Type: TypeArray, Value: Array{ElementType: TypeUnsignedInt8, Values: []interface{}{uint8(1)}}},
+				{Type: TypeStringOctet8, Value: OctetString{0xaa}},
+			},
+			Structure{},
+		}}}
+
+		data, err := bytecodec.Marshal(&typedValueFrame{Value: value})
+		assert.NoError(t, err)
+
+		actualFrame := typedValueFrame{}
+		err = bytecodec.Unmarshal(data, &actualFrame)
+
+		assert.NoError(t, err)
+		assert.Equal(t, value, actualFrame.Value)
+	})
+
+	t.Run("verify options are used for the elements of collections", func(t *testing.T) {
+		value := TypedValue{Type: TypeBag, Value: Bag{ElementType: TypeStringCharacter8, Values: []interface{}{CharacterString("a"), CharacterString("a")}}}
+
+		data, err := bytecodec.Marshal(&typedValueFrame{Value: value})
+		assert.NoError(t, err)
+
+		interner := bytecodec.NewStringInterner(0)
+		actualFrame := typedValueFrame{}
+		err = bytecodec.Unmarshal(data, &actualFrame, bytecodec.InternStrings(interner))
+
+		assert.NoError(t, err)
+		assert.Equal(t, value, actualFrame.Value)
+		assert.Equal(t, 1, interner.Len())
+	})
+
+	t.Run("verify dissect records the elements of collections", func(t *testing.T) {
+		value := TypedValue{Type: TypeArray, Value: Array{ElementType: TypeUnsignedInt8, Values: []interface{}{uint8(1), uint8(2)}}}
+
+		data, err := bytecodec.Marshal(&typedValueFrame{Value: value})
+		assert.NoError(t, err)
+
+		record, err := bytecodec.Dissect(data, &typedValueFrame{})
+		assert.NoError(t, err)
+
+		output := record.String()
+		assert.Contains(t, output, "32+8 collection.Elements[0]")
+		assert.Contains(t, output, "40+8 collection.Elements[1]")
+	})
+
+	t.Run("verify truncated array errors", func(t *testing.T) {
+		actualFrame := typedValueFrame{}
+		err := bytecodec.Unmarshal([]byte{0x48, 0x20, 0x02, 0x00, 0x01}, &actualFrame)
+
+		assert.Error(t, err)
+	})
+}
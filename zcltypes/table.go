@@ -0,0 +1,128 @@
+package zcltypes
+
+import (
+	"reflect"
+)
+
+const VariableSize = -1
+
+type TypeInfo struct {
+	ID         TypeID
+	Name       string
+	Size       int
+	HasInvalid bool
+	Invalid    uint64
+	Type       reflect.Type
+
+	prefixSize int
+}
+
+func newTypeInfo(id TypeID, name string, size int, value interface{}) TypeInfo {
+	info := TypeInfo{ID: id, Name: name, Size: size}
+
+	if value != nil {
+		info.Type = reflect.TypeOf(value)
+	}
+
+	return info
+}
+
+func (t TypeInfo) withInvalid(invalid uint64) TypeInfo {
+	t.HasInvalid = true
+	t.Invalid = invalid
+	return t
+}
+
+func (t TypeInfo) withPrefix(prefixSize int) TypeInfo {
+	t.prefixSize = prefixSize
+	return t
+}
+
+var typeList = []TypeInfo{
+	newTypeInfo(TypeNull, "nodata", 0, Null{}),
+
+	newTypeInfo(TypeData8, "data8", 1, Data8{}),
+	newTypeInfo(TypeData16, "data16", 2, Data16{}),
+	newTypeInfo(TypeData24, "data24", 3, Data24{}),
+	newTypeInfo(TypeData32, "data32", 4, Data32{}),
+	newTypeInfo(TypeData40, "data40", 5, Data40{}),
+	newTypeInfo(TypeData48, "data48", 6, Data48{}),
+	newTypeInfo(TypeData56, "data56", 7, Data56{}),
+	newTypeInfo(TypeData64, "data64", 8, Data64{}),
+
+	newTypeInfo(TypeBoolean, "bool", 1, false).withInvalid(0xff),
+
+	newTypeInfo(TypeBitmap8, "map8", 1, Bitmap8(0)),
+	newTypeInfo(TypeBitmap16, "map16", 2, Bitmap16(0)),
+	newTypeInfo(TypeBitmap24, "map24", 3, Bitmap24(0)),
+	newTypeInfo(TypeBitmap32, "map32", 4, Bitmap32(0)),
+	newTypeInfo(TypeBitmap40, "map40", 5, Bitmap40(0)),
+	newTypeInfo(TypeBitmap48, "map48", 6, Bitmap48(0)),
+	newTypeInfo(TypeBitmap56, "map56", 7, Bitmap56(0)),
+	newTypeInfo(TypeBitmap64, "map64", 8, Bitmap64(0)),
+
+	newTypeInfo(TypeUnsignedInt8, "uint8", 1, uint8(0)).withInvalid(0xff),
+	newTypeInfo(TypeUnsignedInt16, "uint16", 2, uint16(0)).withInvalid(0xffff),
+	newTypeInfo(TypeUnsignedInt24, "uint24", 3, Uint24(0)).withInvalid(0xffffff),
+	newTypeInfo(TypeUnsignedInt32, "uint32", 4, uint32(0)).withInvalid(0xffffffff),
+	newTypeInfo(TypeUnsignedInt40, "uint40", 5, Uint40(0)).withInvalid(0xffffffffff),
+	newTypeInfo(TypeUnsignedInt48, "uint48", 6, Uint48(0)).withInvalid(0xffffffffffff),
+	newTypeInfo(TypeUnsignedInt56, "uint56", 7, Uint56(0)).withInvalid(0xffffffffffffff),
+	newTypeInfo(TypeUnsignedInt64, "uint64", 8, uint64(0)).withInvalid(0xffffffffffffffff),
+
+	newTypeInfo(TypeSignedInt8, "int8", 1, int8(0)).withInvalid(0x80),
+	newTypeInfo(TypeSignedInt16, "int16", 2, int16(0)).withInvalid(0x8000),
+	newTypeInfo(TypeSignedInt24, "int24", 3, Int24(0)).withInvalid(0x800000),
+	newTypeInfo(TypeSignedInt32, "int32", 4, int32(0)).withInvalid(0x80000000),
+	newTypeInfo(TypeSignedInt40, "int40", 5, Int40(0)).withInvalid(0x8000000000),
+	newTypeInfo(TypeSignedInt48, "int48", 6, Int48(0)).withInvalid(0x800000000000),
+	newTypeInfo(TypeSignedInt56, "int56", 7, Int56(0)).withInvalid(0x80000000000000),
+	newTypeInfo(TypeSignedInt64, "int64", 8, int64(0)).withInvalid(0x8000000000000000),
+
+	newTypeInfo(TypeEnum8, "enum8", 1, Enum8(0)).withInvalid(0xff),
+	newTypeInfo(TypeEnum16, "enum16", 2, Enum16(0)).withInvalid(0xffff),
+
+	newTypeInfo(TypeFloatSemi, "semi", 2, FloatSemi(0)).withInvalid(semiNaN),
+	newTypeInfo(TypeFloatSingle, "single", 4, float32(0)).withInvalid(0x7fc00000),
+	newTypeInfo(TypeFloatDouble, "double", 8, float64(0)).withInvalid(0x7ff8000000000000),
+
+	newTypeInfo(TypeStringOctet8, "octstr", VariableSize, OctetString{}).withInvalid(0xff).withPrefix(8),
+	newTypeInfo(TypeStringCharacter8, "string", VariableSize, CharacterString("")).withInvalid(0xff).withPrefix(8),
+	newTypeInfo(TypeStringOctet16, "octstr16", VariableSize, LongOctetString{}).withInvalid(0xffff).withPrefix(16),
+	newTypeInfo(TypeStringCharacter16, "string16", VariableSize, LongCharacterString("")).withInvalid(0xffff).withPrefix(16),
+
+	newTypeInfo(TypeArray, "array", VariableSize, Array{}).withInvalid(0xffff),
+	newTypeInfo(TypeStructure, "struct", VariableSize, Structure{}).withInvalid(0xffff),
+	newTypeInfo(TypeSet, "set", VariableSize, Set{}).withInvalid(0xffff),
+	newTypeInfo(TypeBag, "bag", VariableSize, Bag{}).withInvalid(0xffff),
+
+	newTypeInfo(TypeTimeOfDay, "ToD", 4, TimeOfDay{}).withInvalid(0xffffffff),
+	newTypeInfo(TypeDate, "date", 4, Date{}).withInvalid(0xffffffff),
+	newTypeInfo(TypeUTCTime, "UTC", 4, UTCTime(0)).withInvalid(0xffffffff),
+
+	newTypeInfo(TypeClusterID, "clusterId", 2, ClusterID(0)).withInvalid(0xffff),
+	newTypeInfo(TypeAttributeID, "attribId", 2, AttributeID(0)).withInvalid(0xffff),
+	newTypeInfo(TypeBACnetOID, "bacOID", 4, BACnetOID(0)).withInvalid(0xffffffff),
+
+	newTypeInfo(TypeIEEEAddress, "EUI64", 8, IEEEAddress(0)).withInvalid(0xffffffffffffffff),
+	newTypeInfo(TypeSecurityKey128, "key128", 16, SecurityKey128{}),
+
+	newTypeInfo(TypeUnknown, "unk", 0, nil),
+}
+
+var types = buildTypes(typeList)
+
+func buildTypes(list []TypeInfo) map[TypeID]TypeInfo {
+	m := make(map[TypeID]TypeInfo, len(list))
+
+	for _, info := range list {
+		m[info.ID] = info
+	}
+
+	return m
+}
+
+func Lookup(id TypeID) (TypeInfo, bool) {
+	info, found := types[id]
+	return info, found
+}
@@ -0,0 +1,41 @@
+package zcltypes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/shimmeringbee/bytecodec/bitbuffer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookup(t *testing.T) {
+	t.Run("verify known type is returned", func(t *testing.T) {
+		info, found := Lookup(TypeUnsignedInt24)
+
+		assert.True(t, found)
+		assert.Equal(t, "uint24", info.Name)
+		assert.Equal(t, 3, info.Size)
+		assert.True(t, info.HasInvalid)
+		assert.Equal(t, uint64(0xffffff), info.Invalid)
+		assert.Equal(t, reflect.TypeOf(Uint24(0)), info.Type)
+	})
+
+	t.Run("verify reserved type is not found", func(t *testing.T) {
+		_, found := Lookup(TypeID(0x01))
+		assert.False(t, found)
+	})
+
+	t.Run("verify every fixed size type writes its size", func(t *testing.T) {
+		for _, info := range typeList {
+			if info.Type == nil || info.Size == VariableSize {
+				continue
+			}
+
+			bb := bitbuffer.NewBitBuffer()
+			err := WriteValue(bb, info.ID, reflect.New(info.Type).Elem().Interface())
+
+			assert.NoError(t, err, info.Name)
+			assert.Len(t, bb.Bytes(), info.Size, info.Name)
+		}
+	})
+}
@@ -0,0 +1,107 @@
+package zcltypes
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/shimmeringbee/bytecodec"
+)
+
+const Unspecified uint8 = 0xff
+
+var ErrDateOutOfRange = errors.New("date out of range")
+
+const (
+	dateYearOffset = 1900
+	invalidUTCTime = UTCTime(0xffffffff)
+)
+
+func UTCTimeFromTime(t time.Time) UTCTime {
+	if t.IsZero() || t.Before(bytecodec.ZigbeeEpoch()) {
+		return invalidUTCTime
+	}
+
+	seconds := t.Sub(bytecodec.ZigbeeEpoch()) / time.Second
+
+	if seconds >= time.Duration(invalidUTCTime) {
+		return invalidUTCTime
+	}
+
+	return UTCTime(seconds)
+}
+
+func (u UTCTime) Time() (time.Time, bool) {
+	if u == invalidUTCTime {
+		return time.Time{}, false
+	}
+
+	return bytecodec.ZigbeeEpoch().Add(time.Duration(u) * time.Second), true
+}
+
+func TimeOfDayFromTime(t time.Time) TimeOfDay {
+	return TimeOfDay{
+		Hours:      uint8(t.Hour()),
+		Minutes:    uint8(t.Minute()),
+		Seconds:    uint8(t.Second()),
+		Hundredths: uint8(t.Nanosecond() / int(10*time.Millisecond)),
+	}
+}
+
+func (t TimeOfDay) IsSpecified() bool {
+	return t.Hours != Unspecified && t.Minutes != Unspecified && t.Seconds != Unspecified && t.Hundredths != Unspecified
+}
+
+func (t TimeOfDay) SinceMidnight() (time.Duration, bool) {
+	if !t.IsSpecified() {
+		return 0, false
+	}
+
+	return time.Duration(t.Hours)*time.Hour +
+		time.Duration(t.Minutes)*time.Minute +
+		time.Duration(t.Seconds)*time.Second +
+		time.Duration(t.Hundredths)*10*time.Millisecond, true
+}
+
+// DateFromTime returns the Date of a time, returning ErrDateOutOfRange if its year is before 1900 or after 2154, as the
+// year is held as an offset from 1900 with 255 meaning unspecified.
+func DateFromTime(t time.Time) (Date, error) {
+	year := t.Year() - dateYearOffset
+
+	if year < 0 || year >= int(Unspecified) {
+		return Date{}, fmt.Errorf("%w: year %d", ErrDateOutOfRange, t.Year())
+	}
+
+	dayOfWeek := uint8(t.Weekday())
+
+	if dayOfWeek == 0 {
+		dayOfWeek = 7
+	}
+
+	return Date{
+		Year:       uint8(year),
+		Month:      uint8(t.Month()),
+		DayOfMonth: uint8(t.Day()),
+		DayOfWeek:  dayOfWeek,
+	}, nil
+}
+
+func (d Date) IsSpecified() bool {
+	return d.Year != Unspecified && d.Month != Unspecified && d.DayOfMonth != Unspecified
+}
+
+func (d Date) Time(loc *time.Location) (time.Time, bool) {
+	if !d.IsSpecified() {
+		return time.Time{}, false
+	}
+
+	return time.Date(int(d.Year)+dateYearOffset, time.Month(d.Month), int(d.DayOfMonth), 0, 0, 0, 0, loc), true
+}
+
+func (d Date) Weekday() (time.Weekday, bool) {
+	if d.DayOfWeek == Unspecified || d.DayOfWeek == 0 || d.DayOfWeek > 7 {
+		return 0, false
+	}
+
+	return time.Weekday(d.DayOfWeek % 7), true
+}
@@ -0,0 +1,103 @@
+package zcltypes
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUTCTime(t *testing.T) {
+	t.Run("verify time round trips through utc time", func(t *testing.T) {
+		expectedTime := time.Date(2020, time.March, 4, 5, 6, 7, 0, time.UTC)
+
+		actualTime, valid := UTCTimeFromTime(expectedTime).Time()
+
+		assert.True(t, valid)
+		assert.Equal(t, expectedTime, actualTime)
+	})
+
+	t.Run("verify utc time is seconds since 2000", func(t *testing.T) {
+		assert.Equal(t, UTCTime(86400), UTCTimeFromTime(time.Date(2000, time.January, 2, 0, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("verify zero and pre epoch times are invalid", func(t *testing.T) {
+		assert.Equal(t, UTCTime(0xffffffff), UTCTimeFromTime(time.Time{}))
+		assert.Equal(t, UTCTime(0xffffffff), UTCTimeFromTime(time.Date(1999, time.January, 1, 0, 0, 0, 0, time.UTC)))
+
+		_, valid := UTCTime(0xffffffff).Time()
+		assert.False(t, valid)
+	})
+}
+
+func TestTimeOfDay(t *testing.T) {
+	t.Run("verify time of day is taken from a time", func(t *testing.T) {
+		actualValue := TimeOfDayFromTime(time.Date(2020, time.March, 4, 13, 14, 15, 160000000, time.UTC))
+
+		assert.Equal(t, TimeOfDay{Hours: 13, Minutes: 14, Seconds: 15, Hundredths: 16}, actualValue)
+	})
+
+	t.Run("verify time of day provides duration since midnight", func(t *testing.T) {
+		actualValue, valid := TimeOfDay{Hours: 1, Minutes: 2, Seconds: 3, Hundredths: 4}.SinceMidnight()
+
+		assert.True(t, valid)
+		assert.Equal(t, time.Hour+2*time.Minute+3*time.Second+40*time.Millisecond, actualValue)
+	})
+
+	t.Run("verify unspecified components are reported", func(t *testing.T) {
+		tod := TimeOfDay{Hours: 1, Minutes: 2, Seconds: 3, Hundredths: Unspecified}
+
+		_, valid := tod.SinceMidnight()
+
+		assert.False(t, tod.IsSpecified())
+		assert.False(t, valid)
+	})
+}
+
+func TestDate(t *testing.T) {
+	t.Run("verify date is taken from a time", func(t *testing.T) {
+		actualValue, err := DateFromTime(time.Date(2020, time.March, 8, 13, 14, 15, 0, time.UTC))
+
+		assert.NoError(t, err)
+		assert.Equal(t, Date{Year: 120, Month: 3, DayOfMonth: 8, DayOfWeek: 7}, actualValue)
+	})
+
+	t.Run("verify dates with years which can not be held error", func(t *testing.T) {
+		for _, year := range []int{1899, 2155, 2200} {
+			_, err := DateFromTime(time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC))
+			assert.True(t, errors.Is(err, ErrDateOutOfRange), year)
+		}
+
+		actualValue, err := DateFromTime(time.Date(2154, time.January, 1, 0, 0, 0, 0, time.UTC))
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(254), actualValue.Year)
+	})
+
+	t.Run("verify date converts to a time", func(t *testing.T) {
+		actualValue, valid := Date{Year: 120, Month: 3, DayOfMonth: 8, DayOfWeek: Unspecified}.Time(time.UTC)
+
+		assert.True(t, valid)
+		assert.Equal(t, time.Date(2020, time.March, 8, 0, 0, 0, 0, time.UTC), actualValue)
+	})
+
+	t.Run("verify unspecified date components are reported", func(t *testing.T) {
+		date := Date{Year: 120, Month: Unspecified, DayOfMonth: 8, DayOfWeek: 1}
+
+		_, valid := date.Time(time.UTC)
+
+		assert.False(t, date.IsSpecified())
+		assert.False(t, valid)
+	})
+
+	t.Run("verify day of week is converted to a weekday", func(t *testing.T) {
+		weekday, valid := Date{DayOfWeek: 7}.Weekday()
+
+		assert.True(t, valid)
+		assert.Equal(t, time.Sunday, weekday)
+
+		_, valid = Date{DayOfWeek: Unspecified}.Weekday()
+
+		assert.False(t, valid)
+	})
+}
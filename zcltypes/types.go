@@ -0,0 +1,214 @@
+package zcltypes
+
+import (
+	"math"
+)
+
+type TypeID uint8
+
+const (
+	TypeNull TypeID = 0x00
+
+	TypeData8  TypeID = 0x08
+	TypeData16 TypeID = 0x09
+	TypeData24 TypeID = 0x0a
+	TypeData32 TypeID = 0x0b
+	TypeData40 TypeID = 0x0c
+	TypeData48 TypeID = 0x0d
+	TypeData56 TypeID = 0x0e
+	TypeData64 TypeID = 0x0f
+
+	TypeBoolean TypeID = 0x10
+
+	TypeBitmap8  TypeID = 0x18
+	TypeBitmap16 TypeID = 0x19
+	TypeBitmap24 TypeID = 0x1a
+	TypeBitmap32 TypeID = 0x1b
+	TypeBitmap40 TypeID = 0x1c
+	TypeBitmap48 TypeID = 0x1d
+	TypeBitmap56 TypeID = 0x1e
+	TypeBitmap64 TypeID = 0x1f
+
+	TypeUnsignedInt8  TypeID = 0x20
+	TypeUnsignedInt16 TypeID = 0x21
+	TypeUnsignedInt24 TypeID = 0x22
+	TypeUnsignedInt32 TypeID = 0x23
+	TypeUnsignedInt40 TypeID = 0x24
+	TypeUnsignedInt48 TypeID = 0x25
+	TypeUnsignedInt56 TypeID = 0x26
+	TypeUnsignedInt64 TypeID = 0x27
+
+	TypeSignedInt8  TypeID = 0x28
+	TypeSignedInt16 TypeID = 0x29
+	TypeSignedInt24 TypeID = 0x2a
+	TypeSignedInt32 TypeID = 0x2b
+	TypeSignedInt40 TypeID = 0x2c
+	TypeSignedInt48 TypeID = 0x2d
+	TypeSignedInt56 TypeID = 0x2e
+	TypeSignedInt64 TypeID = 0x2f
+
+	TypeEnum8  TypeID = 0x30
+	TypeEnum16 TypeID = 0x31
+
+	TypeFloatSemi   TypeID = 0x38
+	TypeFloatSingle TypeID = 0x39
+	TypeFloatDouble TypeID = 0x3a
+
+	TypeStringOctet8      TypeID = 0x41
+	TypeStringCharacter8  TypeID = 0x42
+	TypeStringOctet16     TypeID = 0x43
+	TypeStringCharacter16 TypeID = 0x44
+
+	TypeArray     TypeID = 0x48
+	TypeStructure TypeID = 0x4c
+	TypeSet       TypeID = 0x50
+	TypeBag       TypeID = 0x51
+
+	TypeTimeOfDay TypeID = 0xe0
+	TypeDate      TypeID = 0xe1
+	TypeUTCTime   TypeID = 0xe2
+
+	TypeClusterID   TypeID = 0xe8
+	TypeAttributeID TypeID = 0xe9
+	TypeBACnetOID   TypeID = 0xea
+
+	TypeIEEEAddress    TypeID = 0xf0
+	TypeSecurityKey128 TypeID = 0xf1
+
+	TypeUnknown TypeID = 0xff
+)
+
+type Null struct{}
+
+type Data8 [1]byte
+type Data16 [2]byte
+type Data24 [3]byte
+type Data32 [4]byte
+type Data40 [5]byte
+type Data48 [6]byte
+type Data56 [7]byte
+type Data64 [8]byte
+
+type Bitmap8 uint8
+type Bitmap16 uint16
+type Bitmap24 uint32
+type Bitmap32 uint32
+type Bitmap40 uint64
+type Bitmap48 uint64
+type Bitmap56 uint64
+type Bitmap64 uint64
+
+type Uint24 uint32
+type Uint40 uint64
+type Uint48 uint64
+type Uint56 uint64
+
+type Int24 int32
+type Int40 int64
+type Int48 int64
+type Int56 int64
+
+type Enum8 uint8
+type Enum16 uint16
+
+type FloatSemi uint16
+
+type OctetString []byte
+type CharacterString string
+type LongOctetString []byte
+type LongCharacterString string
+
+type TimeOfDay struct {
+	Hours      uint8
+	Minutes    uint8
+	Seconds    uint8
+	Hundredths uint8
+}
+
+type Date struct {
+	Year       uint8
+	Month      uint8
+	DayOfMonth uint8
+	DayOfWeek  uint8
+}
+
+type UTCTime uint32
+
+type ClusterID uint16
+type AttributeID uint16
+type BACnetOID uint32
+
+type IEEEAddress uint64
+
+type SecurityKey128 [16]byte
+
+const (
+	semiExponentBias = 15
+	semiMantissaBits = 10
+	semiExponentMax  = 0x1f
+	semiMantissaMask = 0x3ff
+	semiSignMask     = 0x8000
+	semiInfinity     = 0x7c00
+	semiNaN          = 0x7e00
+)
+
+func NewFloatSemi(f float32) FloatSemi {
+	value := float64(f)
+	sign := FloatSemi(0)
+
+	if math.Signbit(value) {
+		sign = semiSignMask
+		value = -value
+	}
+
+	switch {
+	case math.IsNaN(value):
+		return semiNaN
+	case math.IsInf(value, 0):
+		return sign | semiInfinity
+	case value < math.Ldexp(1, 1-semiExponentBias):
+		mantissa := math.RoundToEven(math.Ldexp(value, semiExponentBias-1+semiMantissaBits))
+		return sign | FloatSemi(mantissa)
+	}
+
+	fraction, exponent := math.Frexp(value)
+	biasedExponent := exponent - 1 + semiExponentBias
+	mantissa := math.RoundToEven((fraction*2 - 1) * (1 << semiMantissaBits))
+
+	if mantissa == 1<<semiMantissaBits {
+		mantissa = 0
+		biasedExponent++
+	}
+
+	if biasedExponent >= semiExponentMax {
+		return sign | semiInfinity
+	}
+
+	return sign | FloatSemi(biasedExponent<<semiMantissaBits) | FloatSemi(mantissa)
+}
+
+func (f FloatSemi) Float32() float32 {
+	exponent := int(f>>semiMantissaBits) & semiExponentMax
+	mantissa := float64(f & semiMantissaMask)
+
+	var value float64
+
+	switch exponent {
+	case 0:
+		value = math.Ldexp(mantissa, 1-semiExponentBias-semiMantissaBits)
+	case semiExponentMax:
+		if mantissa != 0 {
+			return float32(math.NaN())
+		}
+
+		value = math.Inf(1)
+	default:
+		value = math.Ldexp(mantissa+(1<<semiMantissaBits), exponent-semiExponentBias-semiMantissaBits)
+	}
+
+	if f&semiSignMask != 0 {
+		value = -value
+	}
+
+	return float32(value)
+}
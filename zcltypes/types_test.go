@@ -0,0 +1,39 @@
+package zcltypes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFloatSemi(t *testing.T) {
+	t.Run("verify normal values round trip", func(t *testing.T) {
+		for _, value := range []float32{1, -2, 0.5, 1.5, 65504, -0.333251953125} {
+			assert.Equal(t, value, NewFloatSemi(value).Float32())
+		}
+	})
+
+	t.Run("verify known encodings", func(t *testing.T) {
+		assert.Equal(t, FloatSemi(0x3c00), NewFloatSemi(1))
+		assert.Equal(t, FloatSemi(0xc000), NewFloatSemi(-2))
+		assert.Equal(t, FloatSemi(0x7bff), NewFloatSemi(65504))
+		assert.Equal(t, FloatSemi(0x0001), NewFloatSemi(float32(math.Ldexp(1, -24))))
+	})
+
+	t.Run("verify subnormal values round trip", func(t *testing.T) {
+		value := float32(math.Ldexp(3, -24))
+		assert.Equal(t, value, NewFloatSemi(value).Float32())
+	})
+
+	t.Run("verify values too large become infinity", func(t *testing.T) {
+		assert.Equal(t, FloatSemi(0x7c00), NewFloatSemi(70000))
+		assert.Equal(t, FloatSemi(0xfc00), NewFloatSemi(float32(math.Inf(-1))))
+		assert.True(t, math.IsInf(float64(FloatSemi(0x7c00).Float32()), 1))
+	})
+
+	t.Run("verify NaN is preserved", func(t *testing.T) {
+		assert.Equal(t, FloatSemi(0x7e00), NewFloatSemi(float32(math.NaN())))
+		assert.True(t, math.IsNaN(float64(FloatSemi(0x7e00).Float32())))
+	})
+}
@@ -0,0 +1,254 @@
+package zcltypes
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/shimmeringbee/bytecodec"
+	"github.com/shimmeringbee/bytecodec/bitbuffer"
+)
+
+var ErrUnknownType = errors.New("unknown zcl type")
+var ErrUnsupportedType = errors.New("zcl type can not be encoded")
+var ErrValueTypeMismatch = errors.New("value does not match zcl type")
+
+type TypedValue struct {
+	Type  TypeID
+	Value interface{}
+}
+
+func (t *TypedValue) Marshal(bb *bitbuffer.BitBuffer, ctx bytecodec.Context) error {
+	if err := bb.WriteUint(uint64(t.Type), bitbuffer.LittleEndian, 8); err != nil {
+		return err
+	}
+
+	return writeValue(bb, ctx, t.Type, t.Value)
+}
+
+func (t *TypedValue) Unmarshal(bb *bitbuffer.BitBuffer, ctx bytecodec.Context) error {
+	id, err := bb.ReadUint(bitbuffer.LittleEndian, 8)
+	if err != nil {
+		return err
+	}
+
+	value, err := readValue(bb, ctx, TypeID(id))
+	if err != nil {
+		return err
+	}
+
+	t.Type = TypeID(id)
+	t.Value = value
+
+	return nil
+}
+
+func lookupEncodable(id TypeID) (TypeInfo, error) {
+	info, found := Lookup(id)
+
+	if !found {
+		return TypeInfo{}, fmt.Errorf("%w: 0x%02x", ErrUnknownType, uint8(id))
+	}
+
+	if info.Type == nil {
+		return TypeInfo{}, fmt.Errorf("%w: %s", ErrUnsupportedType, info.Name)
+	}
+
+	return info, nil
+}
+
+func WriteValue(bb *bitbuffer.BitBuffer, id TypeID, v interface{}) error {
+	return writeValue(bb, bytecodec.Context{}, id, v)
+}
+
+// writeValue writes a value as WriteValue, marshalling collections and structures with the options of the Context.
+func writeValue(bb *bitbuffer.BitBuffer, ctx bytecodec.Context, id TypeID, v interface{}) error {
+	info, err := lookupEncodable(id)
+	if err != nil {
+		return err
+	}
+
+	value := reflect.ValueOf(v)
+
+	if info.prefixSize > 0 && (!value.IsValid() || (value.Kind() == reflect.Slice && value.IsNil())) {
+		return bb.WriteStringLengthPrefixedNullable(nil, bitbuffer.LittleEndian, info.prefixSize)
+	}
+
+	if !value.IsValid() || kindClass(value.Kind()) != kindClass(info.Type.Kind()) || !value.Type().ConvertibleTo(info.Type) {
+		return fmt.Errorf("%w: %T can not be written as %s", ErrValueTypeMismatch, v, info.Name)
+	}
+
+	value = value.Convert(info.Type)
+	bitSize := info.Size * 8
+
+	switch value.Kind() {
+	case reflect.Bool:
+		return bb.WriteUint(boolToUint(value.Bool()), bitbuffer.LittleEndian, bitSize)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return bb.WriteUint(value.Uint(), bitbuffer.LittleEndian, bitSize)
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return bb.WriteInt(value.Int(), bitbuffer.LittleEndian, bitSize)
+	case reflect.Float32:
+		return bb.WriteUint(uint64(math.Float32bits(float32(value.Float()))), bitbuffer.LittleEndian, bitSize)
+	case reflect.Float64:
+		return bb.WriteUint(math.Float64bits(value.Float()), bitbuffer.LittleEndian, bitSize)
+	case reflect.String:
+		stringValue := value.String()
+		return bb.WriteStringLengthPrefixedNullable(&stringValue, bitbuffer.LittleEndian, info.prefixSize)
+	case reflect.Slice:
+		if info.prefixSize == 0 {
+			return marshalNested(bb, ctx, value)
+		}
+
+		stringValue := string(value.Bytes())
+		return bb.WriteStringLengthPrefixedNullable(&stringValue, bitbuffer.LittleEndian, info.prefixSize)
+	case reflect.Array, reflect.Struct:
+		return marshalNested(bb, ctx, value)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedType, info.Name)
+	}
+}
+
+func ReadValue(bb *bitbuffer.BitBuffer, id TypeID) (interface{}, error) {
+	return readValue(bb, bytecodec.Context{}, id)
+}
+
+// readValue reads a value as ReadValue, unmarshalling collections and structures and interning strings with the options
+// of the Context.
+func readValue(bb *bitbuffer.BitBuffer, ctx bytecodec.Context, id TypeID) (interface{}, error) {
+	info, err := lookupEncodable(id)
+	if err != nil {
+		return nil, err
+	}
+
+	value := reflect.New(info.Type).Elem()
+	bitSize := info.Size * 8
+
+	switch value.Kind() {
+	case reflect.Bool:
+		readValue, err := bb.ReadUint(bitbuffer.LittleEndian, bitSize)
+		if err != nil {
+			return nil, err
+		}
+
+		value.SetBool(readValue > 0)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		readValue, err := bb.ReadUint(bitbuffer.LittleEndian, bitSize)
+		if err != nil {
+			return nil, err
+		}
+
+		value.SetUint(readValue)
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		readValue, err := bb.ReadInt(bitbuffer.LittleEndian, bitSize)
+		if err != nil {
+			return nil, err
+		}
+
+		value.SetInt(readValue)
+	case reflect.Float32:
+		readValue, err := bb.ReadUint(bitbuffer.LittleEndian, bitSize)
+		if err != nil {
+			return nil, err
+		}
+
+		value.SetFloat(float64(math.Float32frombits(uint32(readValue))))
+	case reflect.Float64:
+		readValue, err := bb.ReadUint(bitbuffer.LittleEndian, bitSize)
+		if err != nil {
+			return nil, err
+		}
+
+		value.SetFloat(math.Float64frombits(readValue))
+	case reflect.String:
+		length, err := bb.ReadUint(bitbuffer.LittleEndian, info.prefixSize)
+		if err != nil {
+			return nil, err
+		}
+
+		if length == bitbuffer.InvalidLengthPrefix(info.prefixSize) {
+			return nil, nil
+		}
+
+		data, err := bb.ReadBytesShared(int(length))
+		if err != nil {
+			return nil, err
+		}
+
+		value.SetString(ctx.Intern(data))
+	case reflect.Slice:
+		if info.prefixSize == 0 {
+			if err := unmarshalNested(bb, ctx, value); err != nil {
+				return nil, err
+			}
+
+			break
+		}
+
+		readValue, err := bb.ReadStringLengthPrefixedNullable(bitbuffer.LittleEndian, info.prefixSize)
+		if err != nil {
+			return nil, err
+		}
+
+		if readValue == nil {
+			return nil, nil
+		}
+
+		bytes := make([]byte, len(*readValue))
+		copy(bytes, *readValue)
+
+		value.SetBytes(bytes)
+	case reflect.Array, reflect.Struct:
+		if err := unmarshalNested(bb, ctx, value); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, info.Name)
+	}
+
+	return value.Interface(), nil
+}
+
+// marshalNested marshals a value by its Marshaler if it has one, as bytecodec only uses the Marshaler of a value within
+// a struct, otherwise by its fields or elements.
+func marshalNested(bb *bitbuffer.BitBuffer, ctx bytecodec.Context, value reflect.Value) error {
+	ptr := reflect.New(value.Type())
+	ptr.Elem().Set(value)
+
+	if marshaler, ok := ptr.Interface().(bytecodec.Marshaler); ok {
+		return marshaler.Marshal(bb, ctx)
+	}
+
+	return ctx.MarshalToBitBuffer(bb, ptr.Interface())
+}
+
+// unmarshalNested unmarshals into an addressable value by its Unmarshaler if it has one, as marshalNested.
+func unmarshalNested(bb *bitbuffer.BitBuffer, ctx bytecodec.Context, value reflect.Value) error {
+	if unmarshaler, ok := value.Addr().Interface().(bytecodec.Unmarshaler); ok {
+		return unmarshaler.Unmarshal(bb, ctx)
+	}
+
+	return ctx.UnmarshalFromBitBuffer(bb, value.Addr().Interface())
+}
+
+func kindClass(kind reflect.Kind) reflect.Kind {
+	switch kind {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return reflect.Uint
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return reflect.Int
+	case reflect.Float32, reflect.Float64:
+		return reflect.Float64
+	default:
+		return kind
+	}
+}
+
+func boolToUint(b bool) uint64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
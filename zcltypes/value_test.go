@@ -0,0 +1,205 @@
+package zcltypes
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shimmeringbee/bytecodec"
+	"github.com/shimmeringbee/bytecodec/bitbuffer"
+	"github.com/stretchr/testify/assert"
+)
+
+// typedValueFrame holds a TypedValue as a struct field, which is marshalled by the methods of TypedValue.
+type typedValueFrame struct {
+	Value TypedValue
+}
+
+func TestTypedValue(t *testing.T) {
+	t.Run("verify typed values round trip", func(t *testing.T) {
+		values := []TypedValue{
+			{Type: TypeNull, Value: Null{}},
+			{Type: TypeData16, Value: Data16{0x01, 0x02}},
+			{Type: TypeBoolean, Value: true},
+			{Type: TypeBitmap24, Value: Bitmap24(0x123456)},
+			{Type: TypeUnsignedInt40, Value: Uint40(0x0102030405)},
+			{Type: TypeSignedInt24, Value: Int24(-2)},
+			{Type: TypeSignedInt64, Value: int64(-300)},
+			{Type: TypeEnum16, Value: Enum16(0x0102)},
+			{Type: TypeFloatSemi, Value: NewFloatSemi(1.5)},
+			{Type: TypeFloatSingle, Value: float32(1.25)},
+			{Type: TypeFloatDouble, Value: float64(-8.5)},
+			{Type: TypeStringOctet8, Value: OctetString{0x01, 0x02}},
+			{Type: TypeStringCharacter16, Value: LongCharacterString("abc")},
+			{Type: TypeTimeOfDay, Value: TimeOfDay{Hours: 13, Minutes: 14, Seconds: 15, Hundredths: 16}},
+			{Type: TypeDate, Value: Date{Year: 120, Month: 3, DayOfMonth: 4, DayOfWeek: 3}},
+			{Type: TypeUTCTime, Value: UTCTime(0x01020304)},
+			{Type: TypeIEEEAddress, Value: IEEEAddress(0x0102030405060708)},
+			{Type: TypeSecurityKey128, Value: SecurityKey128{0x01, 0x02}},
+		}
+
+		for _, value := range values {
+			data, err := bytecodec.Marshal(&typedValueFrame{Value: value})
+			assert.NoError(t, err)
+
+			actualFrame := typedValueFrame{}
+			err = bytecodec.Unmarshal(data, &actualFrame)
+
+			assert.NoError(t, err)
+			assert.Equal(t, value, actualFrame.Value)
+		}
+	})
+
+	t.Run("verify typed value is encoded as type id followed by value", func(t *testing.T) {
+		value := TypedValue{Type: TypeUnsignedInt16, Value: uint16(0x0102)}
+
+		data, err := bytecodec.Marshal(&typedValueFrame{Value: value})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x21, 0x02, 0x01}, data)
+	})
+
+	t.Run("verify convertible values are written", func(t *testing.T) {
+		value := TypedValue{Type: TypeSignedInt8, Value: -1}
+
+		data, err := bytecodec.Marshal(&typedValueFrame{Value: value})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x28, 0xff}, data)
+	})
+
+	t.Run("verify typed values can be used as struct fields", func(t *testing.T) {
+		type AttributeReport struct {
+			Identifier AttributeID
+			Value      TypedValue
+		}
+
+		report := AttributeReport{Identifier: 0x0001, Value: TypedValue{Type: TypeStringCharacter8, Value: CharacterString("ab")}}
+
+		data, err := bytecodec.Marshal(report)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x01, 0x00, 0x42, 0x02, 'a', 'b'}, data)
+
+		actualReport := AttributeReport{}
+		err = bytecodec.Unmarshal(data, &actualReport)
+
+		assert.NoError(t, err)
+		assert.Equal(t, report, actualReport)
+	})
+
+	t.Run("verify mismatched value errors", func(t *testing.T) {
+		value := TypedValue{Type: TypeStringCharacter8, Value: 65}
+
+		_, err := bytecodec.Marshal(&typedValueFrame{Value: value})
+
+		assert.True(t, errors.Is(err, ErrValueTypeMismatch))
+	})
+
+	t.Run("verify unknown type errors", func(t *testing.T) {
+		actualFrame := typedValueFrame{}
+		err := bytecodec.Unmarshal([]byte{0x01, 0x00}, &actualFrame)
+
+		assert.True(t, errors.Is(err, ErrUnknownType))
+	})
+
+	t.Run("verify truncated value errors", func(t *testing.T) {
+		actualFrame := typedValueFrame{}
+		err := bytecodec.Unmarshal([]byte{0x2b, 0x00}, &actualFrame)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestWriteValue(t *testing.T) {
+	t.Run("verify integers are written little endian at their zcl width", func(t *testing.T) {
+		bb := bitbuffer.NewBitBuffer()
+
+		err := WriteValue(bb, TypeUnsignedInt24, Uint24(0x010203))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []byte{0x03, 0x02, 0x01}, bb.Bytes())
+	})
+
+	t.Run("verify values exceeding the zcl width error", func(t *testing.T) {
+		bb := bitbuffer.NewBitBuffer()
+
+		err := WriteValue(bb, TypeUnsignedInt24, Uint24(0x01020304))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestInvalidStrings(t *testing.T) {
+	t.Run("verify nil octet strings are written with invalid length", func(t *testing.T) {
+		bb := bitbuffer.NewBitBuffer()
+
+		assert.NoError(t, WriteValue(bb, TypeStringOctet8, OctetString(nil)))
+		assert.NoError(t, WriteValue(bb, TypeStringOctet16, LongOctetString(nil)))
+
+		assert.Equal(t, []byte{0xff, 0xff, 0xff}, bb.Bytes())
+	})
+
+	t.Run("verify nil values for character strings are written with invalid length", func(t *testing.T) {
+		bb := bitbuffer.NewBitBuffer()
+
+		assert.NoError(t, WriteValue(bb, TypeStringCharacter8, nil))
+		assert.NoError(t, WriteValue(bb, TypeStringCharacter16, nil))
+
+		assert.Equal(t, []byte{0xff, 0xff, 0xff}, bb.Bytes())
+	})
+
+	t.Run("verify empty strings are distinct from invalid strings", func(t *testing.T) {
+		bb := bitbuffer.NewBitBuffer()
+
+		assert.NoError(t, WriteValue(bb, TypeStringOctet8, OctetString{}))
+		assert.NoError(t, WriteValue(bb, TypeStringCharacter8, CharacterString("")))
+
+		assert.Equal(t, []byte{0x00, 0x00}, bb.Bytes())
+	})
+
+	t.Run("verify strings using the invalid length are rejected", func(t *testing.T) {
+		bb := bitbuffer.NewBitBuffer()
+
+		err := WriteValue(bb, TypeStringOctet8, make(OctetString, 255))
+
+		assert.True(t, errors.Is(err, bitbuffer.ErrorStringTooLarge))
+	})
+
+	t.Run("verify invalid octet and character strings are both read as a nil value", func(t *testing.T) {
+		for _, id := range []TypeID{TypeStringOctet8, TypeStringCharacter8} {
+			value, err := ReadValue(bitbuffer.NewBitBufferFromBytes([]byte{0xff}), id)
+
+			assert.NoError(t, err)
+			assert.True(t, value == nil, id)
+		}
+
+		for _, id := range []TypeID{TypeStringOctet16, TypeStringCharacter16} {
+			value, err := ReadValue(bitbuffer.NewBitBufferFromBytes([]byte{0xff, 0xff}), id)
+
+			assert.NoError(t, err)
+			assert.True(t, value == nil, id)
+		}
+	})
+
+	t.Run("verify empty octet strings are read as empty", func(t *testing.T) {
+		value, err := ReadValue(bitbuffer.NewBitBufferFromBytes([]byte{0x00}), TypeStringOctet8)
+
+		assert.NoError(t, err)
+		assert.Equal(t, OctetString{}, value)
+		assert.NotNil(t, value)
+	})
+
+	t.Run("verify invalid strings are read as a nil value", func(t *testing.T) {
+		actualFrame := typedValueFrame{}
+		err := bytecodec.Unmarshal([]byte{0x42, 0xff}, &actualFrame)
+
+		assert.NoError(t, err)
+		assert.Equal(t, TypedValue{Type: TypeStringCharacter8}, actualFrame.Value)
+
+		actualFrame = typedValueFrame{}
+		err = bytecodec.Unmarshal([]byte{0x41, 0xff}, &actualFrame)
+
+		assert.NoError(t, err)
+		assert.Equal(t, TypedValue{Type: TypeStringOctet8}, actualFrame.Value)
+	})
+}